@@ -0,0 +1,529 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Segment is line or arc of contour. Arc is the shortest arc from point
+// Begin to point End around point Center, as Circle in gmsh.
+type Segment struct {
+	Begin, End Point
+	Arc        bool  // true for arc
+	Center     Point // center of arc
+}
+
+// Loop is closed contour of segments
+type Loop []Segment
+
+// Boundary is region of section with outer contour and holes
+type Boundary struct {
+	Outer Loop
+	Holes []Loop
+}
+
+// Bounder is section with boundary from lines and arcs
+type Bounder interface {
+	Boundaries() []Boundary
+}
+
+// arcStep is maximal angle of arc between 2 points of contour
+const arcStep = math.Pi / 72.0
+
+// points return points of segment without end point
+func (s Segment) points() (ps []Point) {
+	if !s.Arc {
+		return []Point{s.Begin}
+	}
+	var (
+		a0 = math.Atan2(s.Begin.Y-s.Center.Y, s.Begin.X-s.Center.X)
+		a1 = math.Atan2(s.End.Y-s.Center.Y, s.End.X-s.Center.X)
+		r0 = distance(s.Begin, s.Center)
+		r1 = distance(s.End, s.Center)
+		da = a1 - a0
+	)
+	// shortest arc
+	for da <= -math.Pi {
+		da += 2 * math.Pi
+	}
+	for math.Pi < da {
+		da -= 2 * math.Pi
+	}
+	// tolerance avoids extra point for arcs with rounding errors
+	n := int(math.Ceil(math.Abs(da)/arcStep - Eps))
+	ps = append(ps, s.Begin)
+	for i := 1; i < n; i++ {
+		var (
+			f = float64(i) / float64(n)
+			a = a0 + da*f
+			r = r0 + (r1-r0)*f
+		)
+		ps = append(ps, Point{
+			X: s.Center.X + r*math.Cos(a),
+			Y: s.Center.Y + r*math.Sin(a),
+		})
+	}
+	return
+}
+
+// points return points of contour
+func (l Loop) points() (ps []Point) {
+	for _, s := range l {
+		ps = append(ps, s.points()...)
+	}
+	return
+}
+
+// orientate return contour with counterclockwise points for
+// ccw = true, otherwise clockwise
+func orientate(ps []Point, ccw bool) []Point {
+	if (0 < integrate([][]Point{ps}).A) != ccw {
+		for i, j := 0, len(ps)-1; i < j; i, j = i+1, j-1 {
+			ps[i], ps[j] = ps[j], ps[i]
+		}
+	}
+	return ps
+}
+
+// boundaryPolygons return contours of boundaries with arcs replaced by
+// polylines. Outer contours are counterclockwise, holes are clockwise.
+func boundaryPolygons(bs []Boundary) (rings [][]Point) {
+	for _, b := range bs {
+		rings = append(rings, orientate(b.Outer.points(), true))
+		for _, h := range b.Holes {
+			rings = append(rings, orientate(h.points(), false))
+		}
+	}
+	return
+}
+
+// path is helper for create contour
+type path struct {
+	loop Loop
+	last Point
+}
+
+// start contour from point
+func start(x, y float64) *path {
+	return &path{last: Point{X: x, Y: y}}
+}
+
+// line to point. Segment with zero length is ignored.
+func (p *path) line(x, y float64) *path {
+	end := Point{X: x, Y: y}
+	if end == p.last {
+		return p
+	}
+	p.loop = append(p.loop, Segment{Begin: p.last, End: end})
+	p.last = end
+	return p
+}
+
+// arc around center (xc,yc) to point. Arc with zero radius is ignored.
+func (p *path) arc(xc, yc, x, y float64) *path {
+	end := Point{X: x, Y: y}
+	if end == p.last {
+		return p
+	}
+	p.loop = append(p.loop, Segment{
+		Begin:  p.last,
+		End:    end,
+		Arc:    true,
+		Center: Point{X: xc, Y: yc},
+	})
+	p.last = end
+	return p
+}
+
+// close contour by line to first point
+func (p *path) close() Loop {
+	if len(p.loop) != 0 && p.last != p.loop[0].Begin {
+		p.line(p.loop[0].Begin.X, p.loop[0].Begin.Y)
+	}
+	return p.loop
+}
+
+// BoundaryGeo return gmsh geometry of boundaries. Size of mesh is `prec`.
+func BoundaryGeo(bs []Boundary, prec float64) string {
+	var (
+		buf   bytes.Buffer
+		point = map[Point]int{}
+		id    int // last id of geometry entity
+	)
+	fmt.Fprintf(&buf, "Lc = %.10g;\n", prec)
+	index := func(p Point) int {
+		if i, ok := point[p]; ok {
+			return i
+		}
+		id++
+		point[p] = id
+		fmt.Fprintf(&buf, "Point(%d) = {%.10g, %.10g, 0, Lc};\n", id, p.X, p.Y)
+		return id
+	}
+	loop := func(l Loop) int {
+		var lines []string
+		for _, s := range l {
+			b, e := index(s.Begin), index(s.End)
+			if s.Arc {
+				c := index(s.Center)
+				id++
+				fmt.Fprintf(&buf, "Circle(%d) = {%d, %d, %d};\n", id, b, c, e)
+			} else {
+				id++
+				fmt.Fprintf(&buf, "Line(%d) = {%d, %d};\n", id, b, e)
+			}
+			lines = append(lines, fmt.Sprintf("%d", id))
+		}
+		id++
+		fmt.Fprintf(&buf, "Line Loop(%d) = {%s};\n", id, strings.Join(lines, ", "))
+		return id
+	}
+	for _, b := range bs {
+		loops := []string{fmt.Sprintf("%d", loop(b.Outer))}
+		for _, h := range b.Holes {
+			loops = append(loops, fmt.Sprintf("%d", loop(h)))
+		}
+		id++
+		fmt.Fprintf(&buf, "Plane Surface(%d) = {%s};\n", id, strings.Join(loops, ", "))
+	}
+	return buf.String()
+}
+
+// Boundaries of PlateGroup. Each plate is separate region.
+func (pg PlateGroup) Boundaries() (bs []Boundary) {
+	for _, p := range pg.Plates {
+		bs = append(bs, Boundary{Outer: start(p.Xc-p.X/2.0, p.Yc-p.Y/2.0).
+			line(p.Xc+p.X/2.0, p.Yc-p.Y/2.0).
+			line(p.Xc+p.X/2.0, p.Yc+p.Y/2.0).
+			line(p.Xc-p.X/2.0, p.Yc+p.Y/2.0).
+			close(),
+		})
+	}
+	return
+}
+
+// Boundaries of Rectangle
+func (r Rectangle) Boundaries() []Boundary {
+	return []Boundary{{Outer: start(-r.Thk/2.0, 0).
+		line(+r.Thk/2.0, 0).
+		line(+r.Thk/2.0, r.H).
+		line(-r.Thk/2.0, r.H).
+		close(),
+	}}
+}
+
+// Boundaries of Tsection
+func (t Tsection) Boundaries() []Boundary {
+	return []Boundary{{Outer: start(-t.Thk/2.0, 0).
+		line(-t.L/2.0, 0).
+		line(-t.L/2.0, -t.Thk2/2.0).
+		line(+t.L/2.0, -t.Thk2/2.0).
+		line(+t.L/2.0, 0).
+		line(+t.Thk/2.0, 0).
+		line(+t.Thk/2.0, t.H).
+		line(-t.Thk/2.0, t.H).
+		close(),
+	}}
+}
+
+// Boundaries of Angle
+func (a Angle) Boundaries() []Boundary {
+	var (
+		b   = a.Width
+		thk = a.Thk
+		r1  = a.Radius1
+		r2  = a.Radius2
+	)
+	return []Boundary{{Outer: start(0, 0).
+		line(b, 0).
+		line(b, thk-r2).
+		arc(b-r2, thk-r2, b-r2, thk).
+		line(thk+r1, thk).
+		arc(thk+r1, thk+r1, thk, thk+r1).
+		line(thk, b-r2).
+		arc(thk-r2, b-r2, thk-r2, b).
+		line(0, b).
+		close(),
+	}}
+}
+
+// Boundaries of UnequalAngle
+func (a UnequalAngle) Boundaries() []Boundary {
+	var (
+		b1  = a.Width1
+		b2  = a.Width2
+		thk = a.Thk
+		r1  = a.Radius1
+		r2  = a.Radius2
+	)
+	return []Boundary{{Outer: start(0, 0).
+		line(b2, 0).
+		line(b2, thk-r2).
+		arc(b2-r2, thk-r2, b2-r2, thk).
+		line(thk+r1, thk).
+		arc(thk+r1, thk+r1, thk, thk+r1).
+		line(thk, b1-r2).
+		arc(thk-r2, b1-r2, thk-r2, b1).
+		line(0, b1).
+		close(),
+	}}
+}
+
+// Boundaries of Cylinder
+func (c Cylinder) Boundaries() []Boundary {
+	circle := func(r float64) Loop {
+		return start(r, 0).
+			arc(0, 0, 0, r).
+			arc(0, 0, -r, 0).
+			arc(0, 0, 0, -r).
+			arc(0, 0, r, 0).
+			close()
+	}
+	r := c.Od / 2.0
+	b := Boundary{Outer: circle(r)}
+	if 0 < c.Thk && c.Thk < r {
+		b.Holes = append(b.Holes, circle(r-c.Thk))
+	}
+	return []Boundary{b}
+}
+
+// Boundaries of RHS
+func (r RHS) Boundaries() []Boundary {
+	rectangle := func(h, b, radius float64) []Vertex {
+		return []Vertex{
+			{X: -b / 2, Y: -h / 2, Radius: radius},
+			{X: +b / 2, Y: -h / 2, Radius: radius},
+			{X: +b / 2, Y: +h / 2, Radius: radius},
+			{X: -b / 2, Y: +h / 2, Radius: radius},
+		}
+	}
+	hole := rectangle(r.H-2*r.Thk, r.B-2*r.Thk, r.Ri)
+	for i, j := 0, len(hole)-1; i < j; i, j = i+1, j-1 {
+		hole[i], hole[j] = hole[j], hole[i]
+	}
+	return []Boundary{{
+		Outer: vertexLoop(rectangle(r.H, r.B, r.Ro)),
+		Holes: []Loop{vertexLoop(hole)},
+	}}
+}
+
+// Boundaries of Isection
+func (is Isection) Boundaries() []Boundary {
+	var (
+		h = is.H
+		b = is.B
+		s = is.Tw
+		t = is.Tf
+		r = is.Radius
+	)
+	return []Boundary{{Outer: start(0, 0).
+		line(b, 0).
+		line(b, t).
+		line(b/2+s/2+r, t).
+		arc(b/2+s/2+r, t+r, b/2+s/2, t+r).
+		line(b/2+s/2, h-t-r).
+		arc(b/2+s/2+r, h-t-r, b/2+s/2+r, h-t).
+		line(b, h-t).
+		line(b, h).
+		line(0, h).
+		line(0, h-t).
+		line(b/2-s/2-r, h-t).
+		arc(b/2-s/2-r, h-t-r, b/2-s/2, h-t-r).
+		line(b/2-s/2, t+r).
+		arc(b/2-s/2-r, t+r, b/2-s/2-r, t).
+		line(0, t).
+		close(),
+	}}
+}
+
+// Boundaries of UPN with slope of inner flange surface
+func (u UPN) Boundaries() []Boundary {
+	var (
+		h      = u.H
+		b      = u.B
+		tf     = u.Tf
+		tw     = u.Tw
+		r1     = u.Radius1
+		r2     = u.Radius2
+		w      = b / 2
+		degree = 0.08
+	)
+	if u.Gost {
+		w = (b - tw) / 2
+	}
+	if h > 0.300 {
+		w = (b - tw) / 2
+		degree = 0.05
+	}
+	var (
+		betta = (-math.Atan(degree) + math.Pi/2) / 2
+		// toe of flange
+		yt = tf - w*degree - r2*math.Tan(betta)
+		xe = b - r2 + r2*math.Cos(2*betta)
+		ye = yt + r2*math.Sin(2*betta)
+		// fillet between flange and web
+		yf = tf + (b-tw-w)*degree + r1*math.Tan(betta)
+		xs = tw + r1 - r1*math.Cos(2*betta)
+		ys = yf - r1*math.Sin(2*betta)
+	)
+	return []Boundary{{Outer: start(0, 0).
+		line(b, 0).
+		line(b, yt).
+		arc(b-r2, yt, xe, ye).
+		line(b-w, tf).
+		line(xs, ys).
+		arc(tw+r1, yf, tw, yf).
+		line(tw, h-yf).
+		arc(tw+r1, h-yf, xs, h-ys).
+		line(b-w, h-tf).
+		line(xe, h-ye).
+		arc(b-r2, h-yt, b, h-yt).
+		line(b, h).
+		line(0, h).
+		close(),
+	}}
+}
+
+// fillet return tangent points and center of fillet in vertex `v`
+// between points `a` and `b`. Tangent points is the vertex for sharp
+// corner.
+func fillet(a, v, b Vertex) (t1, t2, c Point, d float64) {
+	p := Point{X: v.X, Y: v.Y}
+	t1, t2 = p, p
+	var (
+		l1 = math.Hypot(a.X-v.X, a.Y-v.Y)
+		l2 = math.Hypot(b.X-v.X, b.Y-v.Y)
+	)
+	if v.Radius <= 0 || l1 == 0 || l2 == 0 {
+		return
+	}
+	var (
+		u1    = Point{X: (a.X - v.X) / l1, Y: (a.Y - v.Y) / l1}
+		u2    = Point{X: (b.X - v.X) / l2, Y: (b.Y - v.Y) / l2}
+		angle = math.Acos(math.Max(-1, math.Min(1, u1.X*u2.X+u1.Y*u2.Y)))
+	)
+	if angle < Eps || math.Pi-Eps < angle {
+		// collinear edges
+		return
+	}
+	d = v.Radius / math.Tan(angle/2.0)
+	t1 = Point{X: v.X + u1.X*d, Y: v.Y + u1.Y*d}
+	t2 = Point{X: v.X + u2.X*d, Y: v.Y + u2.Y*d}
+	var (
+		bx = u1.X + u2.X
+		by = u1.Y + u2.Y
+		bl = math.Hypot(bx, by)
+		r  = v.Radius / math.Sin(angle/2.0)
+	)
+	c = Point{X: v.X + bx/bl*r, Y: v.Y + by/bl*r}
+	return
+}
+
+// loop return contour of vertexes with fillets
+func vertexLoop(vs []Vertex) (l Loop) {
+	if len(vs) < 3 {
+		return
+	}
+	var p *path
+	for i := range vs {
+		var (
+			a = vs[(i+len(vs)-1)%len(vs)]
+			b = vs[(i+1)%len(vs)]
+		)
+		t1, t2, c, _ := fillet(a, vs[i], b)
+		if p == nil {
+			p = start(t1.X, t1.Y)
+		} else if t1 != p.last {
+			p.line(t1.X, t1.Y)
+		}
+		if t1 != t2 {
+			p.arc(c.X, c.Y, t2.X, t2.Y)
+		}
+	}
+	return p.close()
+}
+
+// Boundaries of Polygon
+func (p Polygon) Boundaries() []Boundary {
+	b := Boundary{Outer: vertexLoop(p.Outer)}
+	for _, h := range p.Holes {
+		b.Holes = append(b.Holes, vertexLoop(h))
+	}
+	return []Boundary{b}
+}
+
+// Validate return error for not valid polygon:
+//
+//   - contour with less 3 points or zero length edges
+//   - outer contour is not counterclockwise, hole is not clockwise
+//   - self-intersection of contours
+//   - hole outside of outer contour
+//   - fillet is too big for edges
+func (p Polygon) Validate() error {
+	var (
+		rings = [][]Vertex{p.Outer}
+		ps    [][]Point
+	)
+	rings = append(rings, p.Holes...)
+	for k, r := range rings {
+		name := "outer contour"
+		if k != 0 {
+			name = fmt.Sprintf("hole %d", k-1)
+		}
+		if len(r) < 3 {
+			return fmt.Errorf("%s have less 3 points", name)
+		}
+		var pr []Point
+		for i := range r {
+			if r[i].Radius < 0 {
+				return fmt.Errorf("%s: negative radius in point %d", name, i)
+			}
+			pr = append(pr, Point{X: r[i].X, Y: r[i].Y})
+		}
+		for i := range pr {
+			if pr[i] == pr[(i+1)%len(pr)] {
+				return fmt.Errorf("%s: zero length edge in point %d", name, i)
+			}
+		}
+		ps = append(ps, pr)
+	}
+	if i, j, ok := intersection(ps); ok {
+		return fmt.Errorf("contours have intersection of edges %v and %v", i, j)
+	}
+	for k, r := range rings {
+		name := "outer contour"
+		if k != 0 {
+			name = fmt.Sprintf("hole %d", k-1)
+		}
+		a := integrate([][]Point{ps[k]}).A
+		if k == 0 && a <= 0 {
+			return fmt.Errorf("%s is not counterclockwise", name)
+		}
+		if k != 0 && 0 <= a {
+			return fmt.Errorf("%s is not clockwise", name)
+		}
+		// fillets
+		for i := range r {
+			var (
+				a, v, b = r[(i+len(r)-1)%len(r)], r[i], r[(i+1)%len(r)]
+				c       = r[(i+2)%len(r)]
+			)
+			_, _, _, d1 := fillet(a, v, b)
+			_, _, _, d2 := fillet(v, b, c)
+			if math.Hypot(b.X-v.X, b.Y-v.Y) < d1+d2 {
+				return fmt.Errorf("%s: fillets are too big for edge %d", name, i)
+			}
+		}
+	}
+	for k := 1; k < len(ps); k++ {
+		var other [][]Point
+		other = append(other, ps[:k]...)
+		other = append(other, ps[k+1:]...)
+		if winding(other, ps[k][0]) != 1 {
+			return fmt.Errorf("hole %d is outside of section", k-1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package section
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Konstantin8105/msh"
+)
+
+// Torsion of thin-walled closed cells by Bredt-Batho theory:
+//
+//	q[i]*integral(ds/t, cell i) - sum(q[j]*integral(ds/t, wall i-j)) = 2*A[i]*G*theta
+//	T  = 2 * sum(A[i]*q[i])
+//	It = T / (G*theta)
+//
+// where A[i] is area enclosed by median line of cell walls and q[i] is
+// shear flow of cell. Shear flow in wall between cells i and j is
+// q[i]-q[j].
+//
+// Closed cells are holes of mesh. Integrals of walls are calculated by
+// harmonic functions u[i] with u[i] = 1 on contour of hole i and zero on
+// other contours:
+//
+//	integral(ds/t, cell i)   =  integral(grad(u[i])*grad(u[i]), dA)
+//	integral(ds/t, wall i-j) = -integral(grad(u[i])*grad(u[j]), dA)
+//	A[i] = area of hole i + integral(u[i], dA)
+//
+// See https://en.wikipedia.org/wiki/Torsion_constant#Thin-walled_closed_tube
+type Cell struct {
+	Area float64 // area enclosed by median line
+	Flow float64 // shear flow for unit torque
+}
+
+// Wall is wall between 2 closed cells. Index of cell is -1 for outside.
+// Cells are sorted by center of hole along axe X, then along axe Y.
+type Wall struct {
+	Cells [2]int  // index of cells
+	Flow  float64 // shear flow for unit torque
+}
+
+// bredt return torsion constant of closed cells, cells and walls
+func bredt(mesh msh.Msh, ts []triangle) (it float64, cells []Cell, walls []Wall, err error) {
+	holes, other := boundaryLoops(mesh, ts)
+	if len(holes) == 0 {
+		return
+	}
+	n := len(holes)
+	used := make([]bool, len(mesh.Nodes))
+	for _, t := range ts {
+		for _, i := range t.index {
+			used[i] = true
+		}
+	}
+	// harmonic functions
+	us := make([][]float64, n)
+	for h := range holes {
+		k := stiffness(mesh, ts)
+		g := make([]float64, len(mesh.Nodes))
+		for _, node := range holes[h].nodes {
+			g[node] = 1
+		}
+		f := make([]float64, len(mesh.Nodes))
+		k.multiply(g, f)
+		for i := range f {
+			f[i] = -f[i]
+		}
+		var fix []int
+		for i := range used {
+			if !used[i] {
+				fix = append(fix, i)
+			}
+		}
+		for _, loops := range [][]loop{holes, other} {
+			for _, l := range loops {
+				fix = append(fix, l.nodes...)
+			}
+		}
+		for _, node := range fix {
+			k.fix(node)
+			f[node] = g[node]
+		}
+		us[h], err = k.solve(f)
+		if err != nil {
+			err = fmt.Errorf("closed cells: %v", err)
+			return
+		}
+	}
+	// matrix of walls and areas of cells
+	var (
+		kc = make([][]float64, n)
+		a  = make([]float64, n)
+	)
+	for i := range kc {
+		kc[i] = make([]float64, n)
+		a[i] = holes[i].area
+	}
+	for _, t := range ts {
+		for i := range us {
+			uix, uiy := t.gradient(us[i])
+			for j := range us {
+				ujx, ujy := t.gradient(us[j])
+				kc[i][j] += t.area * (uix*ujx + uiy*ujy)
+			}
+			var ui [3]float64
+			for k := range t.index {
+				ui[k] = us[i][t.index[k]]
+			}
+			a[i] += t.area * (ui[0] + ui[1] + ui[2]) / 3.0
+		}
+	}
+	// shear flows for G*theta = 1
+	rhs := make([]float64, n)
+	for i := range rhs {
+		rhs[i] = 2 * a[i]
+	}
+	q, err := gauss(kc, rhs)
+	if err != nil {
+		err = fmt.Errorf("closed cells: %v", err)
+		return
+	}
+	for i := range q {
+		it += 2 * a[i] * q[i]
+	}
+	if it <= 0 {
+		err = fmt.Errorf("closed cells: torsion constant is not valid: %e", it)
+		return
+	}
+	for i := range q {
+		cells = append(cells, Cell{Area: a[i], Flow: q[i] / it})
+	}
+	// walls
+	for i := range q {
+		outer := kc[i][i]
+		for j := range q {
+			if i == j {
+				continue
+			}
+			outer += kc[i][j]
+			if j < i || -kc[i][j] < Eps*kc[i][i] {
+				continue
+			}
+			walls = append(walls, Wall{Cells: [2]int{i, j}, Flow: (q[i] - q[j]) / it})
+		}
+		if Eps*kc[i][i] < outer {
+			walls = append(walls, Wall{Cells: [2]int{i, -1}, Flow: q[i] / it})
+		}
+	}
+	return
+}
+
+// loop is closed contour of mesh
+type loop struct {
+	nodes  []int   // index of nodes
+	area   float64 // area inside contour
+	xc, yc float64 // center of nodes
+}
+
+// boundaryLoops return holes and outer contours of mesh. Holes are
+// sorted by center along axe X, then along axe Y.
+func boundaryLoops(mesh msh.Msh, ts []triangle) (holes, outer []loop) {
+	// boundary edges with material on the left side
+	type edge struct{ a, b int }
+	count := map[edge]int{}
+	var edges []edge
+	for _, t := range ts {
+		v := t.index
+		if (t.x[1]-t.x[0])*(t.y[2]-t.y[0])-(t.x[2]-t.x[0])*(t.y[1]-t.y[0]) < 0 {
+			v[1], v[2] = v[2], v[1]
+		}
+		for i := range v {
+			e := edge{a: v[i], b: v[(i+1)%3]}
+			count[edge{a: min(e.a, e.b), b: max(e.a, e.b)}]++
+			edges = append(edges, e)
+		}
+	}
+	next := map[int][]int{}
+	var starts []int
+	for _, e := range edges {
+		if count[edge{a: min(e.a, e.b), b: max(e.a, e.b)}] != 1 {
+			continue
+		}
+		if len(next[e.a]) == 0 {
+			starts = append(starts, e.a)
+		}
+		next[e.a] = append(next[e.a], e.b)
+	}
+	for _, s := range starts {
+		if len(next[s]) == 0 {
+			continue
+		}
+		var l loop
+		for node := s; len(next[node]) != 0; {
+			l.nodes = append(l.nodes, node)
+			n := next[node][0]
+			next[node] = next[node][1:]
+			node = n
+		}
+		for i := range l.nodes {
+			var (
+				p1 = mesh.Nodes[l.nodes[i]].Coord
+				p2 = mesh.Nodes[l.nodes[(i+1)%len(l.nodes)]].Coord
+			)
+			l.area += (p1[0]*p2[1] - p2[0]*p1[1]) / 2.0
+			l.xc += p1[0] / float64(len(l.nodes))
+			l.yc += p1[1] / float64(len(l.nodes))
+		}
+		if l.area < 0 {
+			l.area = -l.area
+			holes = append(holes, l)
+			continue
+		}
+		outer = append(outer, l)
+	}
+	sort.SliceStable(holes, func(i, j int) bool {
+		if holes[i].xc != holes[j].xc {
+			return holes[i].xc < holes[j].xc
+		}
+		return holes[i].yc < holes[j].yc
+	})
+	return
+}
+
+// gauss return solution of linear system by Gaussian elimination
+func gauss(a [][]float64, b []float64) (x []float64, err error) {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = append(append([]float64{}, a[i]...), b[i])
+	}
+	for c := 0; c < n; c++ {
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(m[p][c]) < math.Abs(m[r][c]) {
+				p = r
+			}
+		}
+		if m[p][c] == 0 {
+			err = fmt.Errorf("singular matrix")
+			return
+		}
+		m[c], m[p] = m[p], m[c]
+		for r := c + 1; r < n; r++ {
+			f := m[r][c] / m[c][c]
+			for k := c; k <= n; k++ {
+				m[r][k] -= f * m[c][k]
+			}
+		}
+	}
+	x = make([]float64, n)
+	for r := n - 1; 0 <= r; r-- {
+		v := m[r][n]
+		for k := r + 1; k < n; k++ {
+			v -= m[r][k] * x[k]
+		}
+		x[r] = v / m[r][r]
+	}
+	return
+}
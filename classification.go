@@ -0,0 +1,511 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+)
+
+// ElementKind is kind of compression part by EN 1993-1-1 Table 5.2
+type ElementKind int
+
+const (
+	Internal    ElementKind = iota // internal compression part, sheet 1
+	Outstand                       // outstand flange, sheet 2
+	LegOfAngle                     // leg of angle in compression h/t, sheet 3
+	LegsOfAngle                    // angle in compression (b+h)/2t, sheet 3
+)
+
+func (k ElementKind) String() string {
+	switch k {
+	case Internal:
+		return "Internal"
+	case Outstand:
+		return "Outstand"
+	case LegOfAngle:
+		return "Angle h/t"
+	case LegsOfAngle:
+		return "Angle (b+h)/2t"
+	}
+	return fmt.Sprintf("ElementKind(%d)", int(k))
+}
+
+// Element is flat compression part of section with width C and
+// thickness T. Stresses of element are taken on line from Begin to End.
+// Begin is supported edge and End is free edge of outstand.
+//
+// Elements of angle kinds are checked only for compression.
+type Element struct {
+	Name       string
+	Kind       ElementKind
+	C, T       float64
+	Begin, End Point
+}
+
+// Elementer is section with compression parts
+type Elementer interface {
+	Elements() []Element
+}
+
+// ElementClass is class of compression part. Alpha is part of width in
+// compression for plastic stress distribution, Psi is ratio of elastic
+// stresses. Limits is maximal c/t for classes 1, 2, 3 and Limit is
+// governing limit of class. Element in tension is class 1 with infinite
+// limits.
+type ElementClass struct {
+	Element
+	Alpha, Psi float64
+	Ratio      float64 // c/t
+	Limits     [3]float64
+	Limit      float64
+	Class      int
+}
+
+// Classification is class of section by EN 1993-1-1 Table 5.2. Class of
+// section is the biggest class of elements.
+type Classification struct {
+	Load     Load
+	Fy       float64 // yield strength, Pa
+	Epsilon  float64 // sqrt(235 MPa/fy)
+	Class    int
+	Elements []ElementClass
+}
+
+func (c Classification) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Classification by EN 1993-1-1 Table 5.2\n")
+	fmt.Fprintf(w, "Load\t%s\n", c.Load)
+	fmt.Fprintf(w, "fy\t%s\tyield strength\n", efmt.Sprint(c.Fy))
+	fmt.Fprintf(w, "Epsilon\t%s\tsqrt(235 MPa/fy)\n", efmt.Sprint(c.Epsilon))
+	fmt.Fprintf(w, "Class\t%d\tclass of section\n", c.Class)
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Name\tKind\tc\tt\tc/t\tAlpha\tPsi\tLimit1\tLimit2\tLimit3\tLimit\tClass\n")
+	limit := func(l float64) string {
+		if math.IsInf(l, 1) {
+			return "-"
+		}
+		return efmt.Sprint(l)
+	}
+	for _, e := range c.Elements {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			e.Name, e.Kind,
+			efmt.Sprint(e.C), efmt.Sprint(e.T), efmt.Sprint(e.Ratio),
+			efmt.Sprint(e.Alpha), efmt.Sprint(e.Psi),
+			limit(e.Limits[0]), limit(e.Limits[1]),
+			limit(e.Limits[2]), limit(e.Limit),
+			e.Class,
+		)
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// Classify return class of section for load and yield strength fy in Pa.
+// Stresses of elastic distribution are zero at center of section and
+// stresses of plastic distribution are zero on equal-area axe. Axe X is
+// major axe of standard sections.
+func Classify(g Geor, load Load, fy float64) (c Classification, err error) {
+	el, ok := g.(Elementer)
+	if !ok {
+		err = fmt.Errorf("section %s is not Elementer", g.GetName())
+		return
+	}
+	if fy <= 0 {
+		err = fmt.Errorf("yield strength is not positive: %e", fy)
+		return
+	}
+	rings, err := contours(g)
+	if err != nil {
+		return
+	}
+	if rings == nil {
+		err = fmt.Errorf("section %s without contours", g.GetName())
+		return
+	}
+	var (
+		pi = integrate(rings)
+		xc = pi.Sy / pi.A
+		yc = pi.Sx / pi.A
+		// stresses less tolerance are zero
+		tol = Eps * size(rings)
+		// elastic and plastic stresses, compression is positive
+		elastic, plastic func(p Point) float64
+	)
+	switch load {
+	case Compression:
+		elastic = func(Point) float64 { return 1 }
+		plastic = elastic
+	case BendingX:
+		yp := equalArea(rings)
+		elastic = func(p Point) float64 { return p.Y - yc }
+		plastic = func(p Point) float64 { return p.Y - yp }
+	case BendingY:
+		rs := copyPolygons(rings)
+		rotatePolygons(rs, math.Pi/2)
+		xp := equalArea(rs)
+		elastic = func(p Point) float64 { return p.X - xc }
+		plastic = func(p Point) float64 { return p.X - xp }
+	default:
+		err = fmt.Errorf("undefined load: %v", load)
+		return
+	}
+
+	c = Classification{
+		Load:    load,
+		Fy:      fy,
+		Epsilon: math.Sqrt(235e6 / fy),
+		Class:   1,
+	}
+	for _, e := range el.Elements() {
+		if load != Compression && (e.Kind == LegOfAngle || e.Kind == LegsOfAngle) {
+			continue
+		}
+		if e.C <= 0 || e.T <= 0 {
+			err = fmt.Errorf("element %s: sizes are not positive", e.Name)
+			return
+		}
+		stress := func(f func(Point) float64) (s [2]float64) {
+			for i, p := range [2]Point{e.Begin, e.End} {
+				if s[i] = f(p); math.Abs(s[i]) <= tol {
+					s[i] = 0
+				}
+			}
+			return
+		}
+		ec := classify(e, c.Epsilon, stress(elastic), stress(plastic))
+		if c.Class < ec.Class {
+			c.Class = ec.Class
+		}
+		c.Elements = append(c.Elements, ec)
+	}
+	return
+}
+
+// classify return class of element by elastic and plastic stresses on
+// ends of element
+func classify(e Element, eps float64, elastic, plastic [2]float64) (ec ElementClass) {
+	ec.Element = e
+	ec.Ratio = e.C / e.T
+	inf := math.Inf(1)
+	ec.Limits = [3]float64{inf, inf, inf}
+	ec.Limit = inf
+	ec.Class = 1
+
+	if math.Max(elastic[0], elastic[1]) <= 0 && math.Max(plastic[0], plastic[1]) <= 0 {
+		// element in tension
+		return
+	}
+
+	// part of width in compression
+	switch b, e := plastic[0], plastic[1]; {
+	case 0 < b && 0 < e:
+		ec.Alpha = 1
+	case 0 < b:
+		ec.Alpha = b / (b - e)
+	case 0 < e:
+		ec.Alpha = e / (e - b)
+	}
+	alpha := ec.Alpha
+	// compression for elastic stress distribution
+	compressed := 0 < math.Max(elastic[0], elastic[1])
+
+	switch e.Kind {
+	case Internal:
+		if 0.5 < alpha {
+			ec.Limits[0] = 396 * eps / (13*alpha - 1)
+			ec.Limits[1] = 456 * eps / (13*alpha - 1)
+		} else {
+			ec.Limits[0] = 36 * eps / alpha
+			ec.Limits[1] = 41.5 * eps / alpha
+		}
+		if !compressed {
+			break
+		}
+		max, min := math.Max(elastic[0], elastic[1]), math.Min(elastic[0], elastic[1])
+		ec.Psi = min / max
+		if psi := ec.Psi; -1 < psi {
+			ec.Limits[2] = 42 * eps / (0.67 + 0.33*psi)
+		} else {
+			ec.Limits[2] = 62 * eps * (1 - psi) * math.Sqrt(-psi)
+		}
+
+	case Outstand:
+		if 0 < plastic[1] {
+			// tip in compression
+			ec.Limits[0] = 9 * eps / alpha
+			ec.Limits[1] = 10 * eps / alpha
+		} else {
+			// tip in tension
+			ec.Limits[0] = 9 * eps / (alpha * math.Sqrt(alpha))
+			ec.Limits[1] = 10 * eps / (alpha * math.Sqrt(alpha))
+		}
+		if !compressed {
+			break
+		}
+		tip := elastic[0] <= elastic[1]
+		if tip {
+			ec.Psi = elastic[0] / elastic[1]
+		} else {
+			ec.Psi = elastic[1] / elastic[0]
+		}
+		if ec.Psi == 1 {
+			ec.Limits[2] = 14 * eps
+		} else {
+			ec.Limits[2] = 21 * eps * math.Sqrt(outstandFactor(ec.Psi, tip))
+		}
+
+	case LegOfAngle, LegsOfAngle:
+		// only class 3 limit is defined for angles in compression
+		ec.Psi = 1
+		ec.Limits[0], ec.Limits[1] = 0, 0
+		ec.Limits[2] = 15 * eps
+		if e.Kind == LegsOfAngle {
+			ec.Limits[2] = 11.5 * eps
+		}
+	}
+
+	ec.Class = 4
+	ec.Limit = ec.Limits[2]
+	for i, l := range ec.Limits {
+		if ec.Ratio <= l {
+			ec.Class = i + 1
+			ec.Limit = l
+			break
+		}
+	}
+	return
+}
+
+// outstandFactor return buckling factor of outstand by EN 1993-1-5
+// Table 4.2. Maximal compression is on tip for tip = true, otherwise on
+// supported edge. Stress ratio psi is ratio of stress on other edge to
+// maximal compression.
+func outstandFactor(psi float64, tip bool) float64 {
+	switch {
+	case tip:
+		return 0.57 - 0.21*psi + 0.07*psi*psi
+	case 0 <= psi:
+		return 0.578 / (psi + 0.34)
+	}
+	return 1.70 - 5*psi + 17.1*psi*psi
+}
+
+// internalFactor return buckling factor of internal compression part
+// by EN 1993-1-5 Table 4.1
+func internalFactor(psi float64) float64 {
+	switch {
+	case 0 <= psi:
+		return 8.2 / (1.05 + psi)
+	case -1 <= psi:
+		return 7.81 - 6.29*psi + 9.78*psi*psi
+	}
+	return 5.98 * (1 - psi) * (1 - psi)
+}
+
+// size return maximal size of contours
+func size(rings [][]Point) float64 {
+	var (
+		min = Point{X: math.Inf(1), Y: math.Inf(1)}
+		max = Point{X: math.Inf(-1), Y: math.Inf(-1)}
+	)
+	for _, r := range rings {
+		for _, p := range r {
+			min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+			max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+		}
+	}
+	return math.Max(max.X-min.X, max.Y-min.Y)
+}
+
+// Elements of Isection. Widths of rolled section are without root
+// radius.
+func (is Isection) Elements() []Element {
+	var (
+		c  = (is.B - is.Tw - 2*is.Radius) / 2
+		xl = is.B/2 - is.Tw/2 - is.Radius
+		xr = is.B/2 + is.Tw/2 + is.Radius
+		yb = is.Tf / 2
+		yt = is.H - is.Tf/2
+	)
+	return []Element{
+		{Name: "web", Kind: Internal, C: is.H - 2*is.Tf - 2*is.Radius, T: is.Tw,
+			Begin: Point{is.B / 2, is.Tf + is.Radius},
+			End:   Point{is.B / 2, is.H - is.Tf - is.Radius}},
+		{Name: "bottom left flange", Kind: Outstand, C: c, T: is.Tf,
+			Begin: Point{xl, yb}, End: Point{0, yb}},
+		{Name: "bottom right flange", Kind: Outstand, C: c, T: is.Tf,
+			Begin: Point{xr, yb}, End: Point{is.B, yb}},
+		{Name: "top left flange", Kind: Outstand, C: c, T: is.Tf,
+			Begin: Point{xl, yt}, End: Point{0, yt}},
+		{Name: "top right flange", Kind: Outstand, C: c, T: is.Tf,
+			Begin: Point{xr, yt}, End: Point{is.B, yt}},
+	}
+}
+
+// Elements of UPN with thickness of flange Tf
+func (u UPN) Elements() []Element {
+	var (
+		c  = u.B - u.Tw - u.Radius1
+		x  = u.Tw + u.Radius1
+		yb = u.Tf / 2
+		yt = u.H - u.Tf/2
+	)
+	return []Element{
+		{Name: "web", Kind: Internal, C: u.H - 2*u.Tf - 2*u.Radius1, T: u.Tw,
+			Begin: Point{u.Tw / 2, u.Tf + u.Radius1},
+			End:   Point{u.Tw / 2, u.H - u.Tf - u.Radius1}},
+		{Name: "bottom flange", Kind: Outstand, C: c, T: u.Tf,
+			Begin: Point{x, yb}, End: Point{u.B, yb}},
+		{Name: "top flange", Kind: Outstand, C: c, T: u.Tf,
+			Begin: Point{x, yt}, End: Point{u.B, yt}},
+	}
+}
+
+// Elements of Tsection. Web is outstand.
+func (t Tsection) Elements() []Element {
+	var (
+		c  = (t.L - t.Thk) / 2
+		tf = t.Thk2 / 2
+		y  = -tf / 2
+	)
+	return []Element{
+		{Name: "left flange", Kind: Outstand, C: c, T: tf,
+			Begin: Point{-t.Thk / 2, y}, End: Point{-t.L / 2, y}},
+		{Name: "right flange", Kind: Outstand, C: c, T: tf,
+			Begin: Point{t.Thk / 2, y}, End: Point{t.L / 2, y}},
+		{Name: "web", Kind: Outstand, C: t.H, T: t.Thk,
+			Begin: Point{0, 0}, End: Point{0, t.H}},
+	}
+}
+
+// Elements of Angle
+func (a Angle) Elements() []Element {
+	return angleElements(a.Width, a.Width, a.Thk, a.Radius1)
+}
+
+// Elements of UnequalAngle
+func (a UnequalAngle) Elements() []Element {
+	return angleElements(a.Width1, a.Width2, a.Thk, a.Radius1)
+}
+
+// angleElements return elements of angle with leg h along axe Y and
+// leg b along axe X
+func angleElements(h, b, t, r float64) []Element {
+	corner := Point{t / 2, t / 2}
+	return []Element{
+		{Name: "vertical leg", Kind: Outstand, C: h - t - r, T: t,
+			Begin: Point{t / 2, t + r}, End: Point{t / 2, h}},
+		{Name: "horizontal leg", Kind: Outstand, C: b - t - r, T: t,
+			Begin: Point{t + r, t / 2}, End: Point{b, t / 2}},
+		{Name: "long leg", Kind: LegOfAngle, C: math.Max(h, b), T: t,
+			Begin: corner, End: corner},
+		{Name: "legs", Kind: LegsOfAngle, C: (h + b) / 2, T: t,
+			Begin: corner, End: corner},
+	}
+}
+
+// Elements of PlateGroup. Plate is divided by supports of other plates
+// in contact. Part between supports is internal, part of plate end
+// after last support is outstand. Plate without supports have not
+// elements.
+func (pg PlateGroup) Elements() (es []Element) {
+	for _, pe := range pg.plateElements() {
+		es = append(es, pe.Element)
+	}
+	return
+}
+
+// plateElement is element of plate with index Plate. Begin and End of
+// element are located on S and E along long side of plate.
+type plateElement struct {
+	Element
+	Plate int
+	S, E  float64
+}
+
+// plateElements return elements of PlateGroup
+func (pg PlateGroup) plateElements() (es []plateElement) {
+	// along return interval of plate along long side and point on
+	// centerline of plate
+	along := func(p Plate) (a, b float64, point func(s float64) Point) {
+		if p.Y <= p.X {
+			return p.Xc - p.X/2, p.Xc + p.X/2,
+				func(s float64) Point { return Point{s, p.Yc} }
+		}
+		return p.Yc - p.Y/2, p.Yc + p.Y/2,
+			func(s float64) Point { return Point{p.Xc, s} }
+	}
+	for i, p := range pg.Plates {
+		a, b, point := along(p)
+		tol := Eps * (b - a)
+		// supports on plate
+		type support struct{ from, to float64 }
+		var ss []support
+		for j, q := range pg.Plates {
+			if i == j {
+				continue
+			}
+			if p.Xc-p.X/2 > q.Xc+q.X/2+tol || q.Xc-q.X/2 > p.Xc+p.X/2+tol ||
+				p.Yc-p.Y/2 > q.Yc+q.Y/2+tol || q.Yc-q.Y/2 > p.Yc+p.Y/2+tol {
+				// plates without contact
+				continue
+			}
+			var s support
+			if p.Y <= p.X {
+				s = support{q.Xc - q.X/2, q.Xc + q.X/2}
+			} else {
+				s = support{q.Yc - q.Y/2, q.Yc + q.Y/2}
+			}
+			ss = append(ss, support{math.Max(a, s.from), math.Min(b, s.to)})
+		}
+		if len(ss) == 0 {
+			continue
+		}
+		sort.Slice(ss, func(i, j int) bool { return ss[i].from < ss[j].from })
+		// merge supports
+		merged := ss[:1]
+		for _, s := range ss[1:] {
+			last := &merged[len(merged)-1]
+			if s.from <= last.to+tol {
+				last.to = math.Max(last.to, s.to)
+				continue
+			}
+			merged = append(merged, s)
+		}
+		ss = merged
+
+		t := math.Min(p.X, p.Y)
+		// element from supported edge `s` to edge `e`
+		add := func(kind ElementKind, s, e float64) {
+			from, to := math.Min(s, e), math.Max(s, e)
+			if to-from <= tol {
+				return
+			}
+			es = append(es, plateElement{
+				Element: Element{
+					Name:  fmt.Sprintf("plate %d, %s", i, kind),
+					Kind:  kind,
+					C:     to - from,
+					T:     t,
+					Begin: point(s),
+					End:   point(e),
+				},
+				Plate: i,
+				S:     s,
+				E:     e,
+			})
+		}
+		add(Outstand, ss[0].from, a)
+		for k := 1; k < len(ss); k++ {
+			add(Internal, ss[k-1].to, ss[k].from)
+		}
+		add(Outstand, ss[len(ss)-1].to, b)
+	}
+	return
+}
@@ -0,0 +1,356 @@
+package section
+
+import "fmt"
+
+// Cold-formed sections have uniform thickness and bends with inner
+// radius. Contour of section is created by offset of center line on
+// half of thickness to both sides:
+//
+//	rm = r + t/2 - radius of center line in bend
+//	r       - inner radius of bend
+//	r + t   - outer radius of bend
+//
+// Sizes of sections are outer sizes.
+
+// LippedChannel is cold-formed lipped channel (C-section).
+//
+//	  |--B--|
+//	--*******
+//	| *     * C
+//	| *
+//	H * Thk
+//	| *
+//	| *     * C
+//	--*******
+type LippedChannel struct {
+	Name string
+	H    float64 // height
+	B    float64 // width of flange
+	C    float64 // length of lip
+	Thk  float64 // thickness
+	R    float64 // inner radius of bends
+}
+
+func (l LippedChannel) GetName() string {
+	if l.Name == "" {
+		return fmt.Sprintf("C%gx%gx%gx%g", 1e3*l.H, 1e3*l.B, 1e3*l.C, 1e3*l.Thk)
+	}
+	return l.Name
+}
+
+func (l LippedChannel) Geo(prec float64) string {
+	return BoundaryGeo(l.Boundaries(), prec)
+}
+
+func (l LippedChannel) centerLine() []Point {
+	var (
+		h = l.H - l.Thk/2
+		b = l.B - l.Thk/2
+		t = l.Thk / 2
+	)
+	return []Point{{b, l.C}, {b, t}, {t, t}, {t, h}, {b, h}, {b, l.H - l.C}}
+}
+
+func (l LippedChannel) Validate() error {
+	return validateStrip(l.centerLine(), l.Thk, l.R)
+}
+
+// Boundaries of LippedChannel
+func (l LippedChannel) Boundaries() []Boundary {
+	return []Boundary{{Outer: strip(l.centerLine(), l.Thk, l.R)}}
+}
+
+// LippedChannels is cold-formed lipped channels
+var LippedChannels = []LippedChannel{
+	{"C100x50x15x1.5", 0.100, 0.050, 0.015, 0.0015, 0.0030},
+	{"C120x50x15x2.0", 0.120, 0.050, 0.015, 0.0020, 0.0030},
+	{"C150x65x15x2.0", 0.150, 0.065, 0.015, 0.0020, 0.0030},
+	{"C150x65x20x2.5", 0.150, 0.065, 0.020, 0.0025, 0.0030},
+	{"C200x75x20x2.0", 0.200, 0.075, 0.020, 0.0020, 0.0030},
+	{"C200x75x20x2.5", 0.200, 0.075, 0.020, 0.0025, 0.0030},
+	{"C250x75x20x2.5", 0.250, 0.075, 0.020, 0.0025, 0.0030},
+	{"C300x90x25x3.0", 0.300, 0.090, 0.025, 0.0030, 0.0045},
+}
+
+// LippedZ is cold-formed lipped Z-section with top flange B1 and bottom
+// flange B2. Flanges may be unequal for lapping of purlins.
+//
+//	     |--B1--|
+//	   --********
+//	   | *      * C
+//	   H * Thk
+//	   | *
+//	 C * *
+//	   --****
+//	|--B2--|
+type LippedZ struct {
+	Name string
+	H    float64 // height
+	B1   float64 // width of top flange
+	B2   float64 // width of bottom flange
+	C    float64 // length of lip
+	Thk  float64 // thickness
+	R    float64 // inner radius of bends
+}
+
+func (z LippedZ) GetName() string {
+	if z.Name == "" {
+		return fmt.Sprintf("Z%gx%gx%gx%gx%g",
+			1e3*z.H, 1e3*z.B1, 1e3*z.B2, 1e3*z.C, 1e3*z.Thk)
+	}
+	return z.Name
+}
+
+func (z LippedZ) Geo(prec float64) string {
+	return BoundaryGeo(z.Boundaries(), prec)
+}
+
+func (z LippedZ) centerLine() []Point {
+	var (
+		h  = z.H - z.Thk/2
+		b1 = z.B1 - z.Thk/2
+		b2 = z.Thk*1.5 - z.B2
+		t  = z.Thk / 2
+	)
+	return []Point{{b1, z.H - z.C}, {b1, h}, {t, h}, {t, t}, {b2, t}, {b2, z.C}}
+}
+
+func (z LippedZ) Validate() error {
+	return validateStrip(z.centerLine(), z.Thk, z.R)
+}
+
+// Boundaries of LippedZ
+func (z LippedZ) Boundaries() []Boundary {
+	return []Boundary{{Outer: strip(z.centerLine(), z.Thk, z.R)}}
+}
+
+// LippedZs is cold-formed lipped Z-sections
+var LippedZs = []LippedZ{
+	{"Z150x62x68x18x1.5", 0.150, 0.062, 0.068, 0.018, 0.0015, 0.0030},
+	{"Z200x62x68x18x2.0", 0.200, 0.062, 0.068, 0.018, 0.0020, 0.0030},
+	{"Z200x62x68x20x2.5", 0.200, 0.062, 0.068, 0.020, 0.0025, 0.0030},
+	{"Z250x72x78x20x2.5", 0.250, 0.072, 0.078, 0.020, 0.0025, 0.0030},
+	{"Z300x95x101x25x3.0", 0.300, 0.095, 0.101, 0.025, 0.0030, 0.0045},
+}
+
+// Sigma is cold-formed sigma section. Web has stiffener with depth D
+// and inclined parts on 45 degree.
+//
+//	  |--B--|
+//	--*******
+//	| *     * C
+//	| * S
+//	|  *
+//	H   * D
+//	|  *
+//	| * S
+//	| *     * C
+//	--*******
+type Sigma struct {
+	Name string
+	H    float64 // height
+	B    float64 // width of flange
+	C    float64 // length of lip
+	D    float64 // depth of web stiffener
+	S    float64 // length of outer parts of web
+	Thk  float64 // thickness
+	R    float64 // inner radius of bends
+}
+
+func (s Sigma) GetName() string {
+	if s.Name == "" {
+		return fmt.Sprintf("Sigma%gx%gx%gx%g", 1e3*s.H, 1e3*s.B, 1e3*s.C, 1e3*s.Thk)
+	}
+	return s.Name
+}
+
+func (s Sigma) Geo(prec float64) string {
+	return BoundaryGeo(s.Boundaries(), prec)
+}
+
+func (s Sigma) centerLine() []Point {
+	var (
+		h = s.H - s.Thk/2
+		b = s.B - s.Thk/2
+		t = s.Thk / 2
+	)
+	return []Point{
+		{b, s.H - s.C}, {b, h}, {t, h},
+		{t, s.H - s.S}, {t + s.D, s.H - s.S - s.D},
+		{t + s.D, s.S + s.D}, {t, s.S},
+		{t, t}, {b, t}, {b, s.C},
+	}
+}
+
+func (s Sigma) Validate() error {
+	return validateStrip(s.centerLine(), s.Thk, s.R)
+}
+
+// Boundaries of Sigma
+func (s Sigma) Boundaries() []Boundary {
+	return []Boundary{{Outer: strip(s.centerLine(), s.Thk, s.R)}}
+}
+
+// Sigmas is cold-formed sigma sections
+var Sigmas = []Sigma{
+	{"Sigma150x62x18x1.5", 0.150, 0.062, 0.018, 0.010, 0.035, 0.0015, 0.0030},
+	{"Sigma200x62x20x2.0", 0.200, 0.062, 0.020, 0.012, 0.050, 0.0020, 0.0030},
+	{"Sigma250x62x20x2.5", 0.250, 0.062, 0.020, 0.015, 0.060, 0.0025, 0.0030},
+	{"Sigma300x75x20x3.0", 0.300, 0.075, 0.020, 0.018, 0.070, 0.0030, 0.0045},
+}
+
+// Hat is cold-formed top-hat section.
+//
+//	      |--B--|
+//	    --*******
+//	    | *     *
+//	    H *     * Thk
+//	    | *     *
+//	*******     *******
+//	|-F-|         |-F-|
+type Hat struct {
+	Name string
+	H    float64 // height
+	B    float64 // width of crown
+	F    float64 // width of flange
+	Thk  float64 // thickness
+	R    float64 // inner radius of bends
+}
+
+func (h Hat) GetName() string {
+	if h.Name == "" {
+		return fmt.Sprintf("Hat%gx%gx%gx%g", 1e3*h.H, 1e3*h.B, 1e3*h.F, 1e3*h.Thk)
+	}
+	return h.Name
+}
+
+func (h Hat) Geo(prec float64) string {
+	return BoundaryGeo(h.Boundaries(), prec)
+}
+
+func (h Hat) centerLine() []Point {
+	var (
+		t  = h.Thk / 2
+		x1 = h.F + t
+		x2 = h.F + h.B - t
+		y  = h.H - t
+	)
+	return []Point{{0, t}, {x1, t}, {x1, y}, {x2, y}, {x2, t}, {2*h.F + h.B, t}}
+}
+
+func (h Hat) Validate() error {
+	return validateStrip(h.centerLine(), h.Thk, h.R)
+}
+
+// Boundaries of Hat
+func (h Hat) Boundaries() []Boundary {
+	return []Boundary{{Outer: strip(h.centerLine(), h.Thk, h.R)}}
+}
+
+// Hats is cold-formed top-hat sections
+var Hats = []Hat{
+	{"Hat35x50x25x1.5", 0.035, 0.050, 0.025, 0.0015, 0.0020},
+	{"Hat50x60x30x2.0", 0.050, 0.060, 0.030, 0.0020, 0.0030},
+	{"Hat70x80x40x2.5", 0.070, 0.080, 0.040, 0.0025, 0.0030},
+	{"Hat100x100x40x3.0", 0.100, 0.100, 0.040, 0.0030, 0.0045},
+}
+
+// bends return tangent points and centers of bends of center line with
+// radius `rm` in inner points of center line
+func bends(ps []Point, rm float64) (t1, t2, c []Point, d []float64) {
+	n := len(ps)
+	t1, t2, c, d = make([]Point, n), make([]Point, n), make([]Point, n), make([]float64, n)
+	t1[0], t2[0] = ps[0], ps[0]
+	t1[n-1], t2[n-1] = ps[n-1], ps[n-1]
+	for i := 1; i < n-1; i++ {
+		t1[i], t2[i], c[i], d[i] = fillet(
+			Vertex{X: ps[i-1].X, Y: ps[i-1].Y},
+			Vertex{X: ps[i].X, Y: ps[i].Y, Radius: rm},
+			Vertex{X: ps[i+1].X, Y: ps[i+1].Y},
+		)
+	}
+	return
+}
+
+// strip return contour of strip with thickness `t` along center line
+// `ps` with bends with inner radius `r`
+func strip(ps []Point, t, r float64) Loop {
+	if len(ps) < 2 {
+		return nil
+	}
+	t1, t2, c, _ := bends(ps, r+t/2)
+	// normal to the left side of segment
+	normal := func(i int, side float64) Point {
+		var (
+			a = ps[i]
+			b = ps[i+1]
+			l = distance(a, b)
+		)
+		return Point{X: -(b.Y - a.Y) / l * side * t / 2, Y: (b.X - a.X) / l * side * t / 2}
+	}
+	add := func(p, n Point) Point {
+		return Point{X: p.X + n.X, Y: p.Y + n.Y}
+	}
+	var (
+		last = len(ps) - 1
+		n    = normal(0, 1)
+		p0   = add(ps[0], n)
+		p    = start(p0.X, p0.Y)
+	)
+	// left side
+	for i := 1; i < last; i++ {
+		var (
+			n1 = normal(i-1, 1)
+			n2 = normal(i, 1)
+			b  = add(t1[i], n1)
+			e  = add(t2[i], n2)
+		)
+		p.line(b.X, b.Y)
+		p.arc(c[i].X, c[i].Y, e.X, e.Y)
+	}
+	e := add(ps[last], normal(last-1, 1))
+	p.line(e.X, e.Y)
+	// right side
+	e = add(ps[last], normal(last-1, -1))
+	p.line(e.X, e.Y)
+	for i := last - 1; 0 < i; i-- {
+		var (
+			n1 = normal(i-1, -1)
+			n2 = normal(i, -1)
+			b  = add(t2[i], n2)
+			e  = add(t1[i], n1)
+		)
+		p.line(b.X, b.Y)
+		p.arc(c[i].X, c[i].Y, e.X, e.Y)
+	}
+	e = add(ps[0], normal(0, -1))
+	p.line(e.X, e.Y)
+	return p.close()
+}
+
+// validateStrip return error for not valid strip, see strip
+func validateStrip(ps []Point, t, r float64) error {
+	switch {
+	case t <= 0:
+		return fmt.Errorf("thickness is not positive")
+	case r < 0:
+		return fmt.Errorf("negative radius")
+	}
+	for i := 1; i < len(ps); i++ {
+		if distance(ps[i-1], ps[i]) <= 0 {
+			return fmt.Errorf("sizes of section are not valid")
+		}
+	}
+	_, _, _, d := bends(ps, r+t/2)
+	for i := 1; i < len(ps); i++ {
+		if l := distance(ps[i-1], ps[i]); l < d[i-1]+d[i] {
+			return fmt.Errorf("radius is too big for element %d", i-1)
+		}
+	}
+	if e1, e2, ok := intersection(boundaryPolygons([]Boundary{{
+		Outer: strip(ps, t, r),
+	}})); ok {
+		return fmt.Errorf("intersection of elements %v and %v", e1, e2)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+)
+
+// Part is section in compound section. Boundaries of section are
+// mirrored by axe Y if Mirror is true, then rotated on angle Rotate
+// around origin, then moved on (X,Y). Section must be Bounder.
+type Part struct {
+	Geor
+	Mirror bool    // mirror by axe Y: x -> -x
+	Rotate float64 // angle of rotation, radian
+	X, Y   float64 // translation
+}
+
+// Compound is built-up section from parts. Parts must not overlap,
+// parts with common edges are connected.
+//
+// Example of back-to-back angles:
+//
+//	Compound{Parts: []Part{
+//		{Geor: Angles[0], X: gap / 2},
+//		{Geor: Angles[0], Mirror: true, X: -gap / 2},
+//	}}
+type Compound struct {
+	Name  string
+	Parts []Part
+}
+
+func (c Compound) GetName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "%s\n", "Compound")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "№\tName\tMirror\tRotate\tX\tY\n")
+	for i, p := range c.Parts {
+		name := "Undefined"
+		if p.Geor != nil {
+			name = p.GetName()
+		}
+		fmt.Fprintf(w,
+			"%d\t%s\t%v\t%s\t%s\t%s\n",
+			i, name, p.Mirror,
+			efmt.Sprint(p.Rotate),
+			efmt.Sprint(p.X), efmt.Sprint(p.Y),
+		)
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+func (c Compound) Geo(prec float64) string {
+	return BoundaryGeo(c.Boundaries(), prec)
+}
+
+func (c Compound) Validate() error {
+	if len(c.Parts) == 0 {
+		return fmt.Errorf("compound section without parts")
+	}
+	for i, p := range c.Parts {
+		if err := validateBounder(p.Geor); err != nil {
+			return fmt.Errorf("part %d: %v", i, err)
+		}
+	}
+	rings := make([][][]Point, len(c.Parts))
+	for i, p := range c.Parts {
+		rings[i] = boundaryPolygons(transformed(p.Geor, p.Mirror, p.Rotate, p.X, p.Y))
+		for j := 0; j < i; j++ {
+			if overlap(rings[j], rings[i]) {
+				return fmt.Errorf("parts %d and %d are overlapped", j, i)
+			}
+		}
+	}
+	return nil
+}
+
+// Boundaries of Compound. Parts without boundaries are ignored.
+func (c Compound) Boundaries() (bs []Boundary) {
+	for _, p := range c.Parts {
+		bs = append(bs, transformed(p.Geor, p.Mirror, p.Rotate, p.X, p.Y)...)
+	}
+	return
+}
+
+// BackToBackAngles return 2 angles with vertical legs back to back and
+// gap between legs. Angle is Angle or UnequalAngle with legs along
+// axes X and Y.
+//
+//	    *|gap|*
+//	    *|   |*
+//	    *|   |*
+//	*****|   |*****
+func BackToBackAngles(a Geor, gap float64) Compound {
+	return Compound{
+		Name: fmt.Sprintf("2x%s back-to-back, gap %g mm", a.GetName(), gap*1e3),
+		Parts: []Part{
+			{Geor: a, X: gap / 2},
+			{Geor: a, Mirror: true, X: -gap / 2},
+		},
+	}
+}
+
+// StarAngles return 2 angles in star (cruciform) form with gap between
+// legs. Angle is Angle or UnequalAngle with legs along axes X and Y.
+//
+//	     |   |*
+//	     |gap|*
+//	     |   |*****
+//	*****|   |
+//	    *|   |
+//	    *|   |
+func StarAngles(a Geor, gap float64) Compound {
+	return Compound{
+		Name: fmt.Sprintf("2x%s star, gap %g mm", a.GetName(), gap*1e3),
+		Parts: []Part{
+			{Geor: a, X: gap / 2, Y: gap / 2},
+			{Geor: a, Rotate: math.Pi, X: -gap / 2, Y: -gap / 2},
+		},
+	}
+}
+
+// DoubleChannels return 2 channels with webs back to back and gap
+// between webs, or toe to toe with gap between flanges.
+//
+//	back to back:        toe to toe:
+//
+//	****|gap|****        ****   |gap|   ****
+//	   *|   |*           *      |   |      *
+//	****|   |****        ****   |   |   ****
+func DoubleChannels(u UPN, gap float64, toeToToe bool) Compound {
+	if toeToToe {
+		return Compound{
+			Name: fmt.Sprintf("2x%s toe-to-toe, gap %g mm", u.GetName(), gap*1e3),
+			Parts: []Part{
+				{Geor: u, Mirror: true, X: u.B + gap/2},
+				{Geor: u, X: -u.B - gap/2},
+			},
+		}
+	}
+	return Compound{
+		Name: fmt.Sprintf("2x%s back-to-back, gap %g mm", u.GetName(), gap*1e3),
+		Parts: []Part{
+			{Geor: u, X: gap / 2},
+			{Geor: u, Mirror: true, X: -gap / 2},
+		},
+	}
+}
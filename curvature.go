@@ -0,0 +1,358 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+)
+
+// LawRegion is region of section with stress-strain law. Section must be
+// Bounder.
+type LawRegion struct {
+	Geor
+	Law Law
+}
+
+// LawBar is bar with stress-strain law. Bar displaces law of region at
+// center of bar.
+type LawBar struct {
+	Bar
+	Law Law
+}
+
+// Nonlinear is section from regions and bars with nonlinear stress-strain
+// laws. Regions must not overlap.
+type Nonlinear struct {
+	Name    string
+	Regions []LawRegion
+	Bars    []LawBar
+}
+
+// Nonlinear return section of concrete with reinforcement bars
+func (rc RC) Nonlinear() Nonlinear {
+	s := Nonlinear{
+		Name:    rc.GetName(),
+		Regions: []LawRegion{{Geor: rc.Section, Law: rc.Concrete}},
+	}
+	for _, b := range rc.Bars {
+		s.Bars = append(s.Bars, LawBar{Bar: b, Law: rc.Rebar})
+	}
+	return s
+}
+
+func (s Nonlinear) Validate() error {
+	if len(s.Regions) == 0 {
+		return fmt.Errorf("nonlinear section without regions")
+	}
+	for i, r := range s.Regions {
+		if err := validateBounder(r.Geor); err != nil {
+			return fmt.Errorf("region %d: %v", i, err)
+		}
+		if r.Law == nil {
+			return fmt.Errorf("region %d: undefined law", i)
+		}
+	}
+	rings := make([][][]Point, len(s.Regions))
+	for i, r := range s.Regions {
+		rings[i] = boundaryPolygons(transformed(r.Geor, false, 0, 0, 0))
+		for j := 0; j < i; j++ {
+			if overlap(rings[j], rings[i]) {
+				return fmt.Errorf("regions %d and %d are overlapped", j, i)
+			}
+		}
+	}
+	for i, b := range s.Bars {
+		if b.Diameter <= 0 {
+			return fmt.Errorf("bar %d: diameter is not positive", i)
+		}
+		if b.Law == nil {
+			return fmt.Errorf("bar %d: undefined law", i)
+		}
+	}
+	return nil
+}
+
+// CurvaturePoint is state of section for curvature. Strain is strain at
+// center of section.
+type CurvaturePoint struct {
+	Curvature float64
+	Strain    float64
+	M         float64 // moment around neutral axe
+	Mx, My    float64
+}
+
+// MomentCurvature is moment-curvature curve of section for axial force N
+// and angle of neutral axe from axe X. Yield is point of the first fibre
+// reaching yield strain of law, Ultimate is point of the first fibre
+// reaching ultimate strain.
+type MomentCurvature struct {
+	N, Angle float64
+	Curve    []CurvaturePoint
+	Yield    CurvaturePoint
+	Ultimate CurvaturePoint
+}
+
+func (mc MomentCurvature) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Moment-curvature\n")
+	fmt.Fprintf(w, "N\t%s\taxial force\n", efmt.Sprint(mc.N))
+	fmt.Fprintf(w, "Angle\t%s\tangle of neutral axe\n", efmt.Sprint(mc.Angle))
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Point\tCurvature\tStrain\tM\tMx\tMy\n")
+	row := func(name string, p CurvaturePoint) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", name,
+			efmt.Sprint(p.Curvature), efmt.Sprint(p.Strain),
+			efmt.Sprint(p.M), efmt.Sprint(p.Mx), efmt.Sprint(p.My))
+	}
+	for i, p := range mc.Curve {
+		row(fmt.Sprintf("%d", i), p)
+	}
+	row("Yield", mc.Yield)
+	row("Ultimate", mc.Ultimate)
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// CalculateCurvature return moment-curvature curve of nonlinear section
+// for axial force n, positive in compression, and angle of neutral axe
+// from axe X. Strain plane is rotated around center of regions:
+//
+//	strain = Strain + Curvature*(-x*sin(angle) + y*cos(angle))
+//
+// so angle 0 is bending around axe X with compression of fibres with
+// positive Y. Curve has steps+1 points with equal steps of curvature from
+// zero to ultimate curvature. For each curvature strain at center is
+// found by bisection for equilibrium of axial force.
+func CalculateCurvature(s Nonlinear, n, angle float64, steps int) (mc MomentCurvature, err error) {
+	if err = s.Validate(); err != nil {
+		return
+	}
+	if steps < 1 {
+		err = fmt.Errorf("not valid amount of steps: %d", steps)
+		return
+	}
+	mc.N, mc.Angle = n, angle
+	rings := make([][][]Point, len(s.Regions))
+	var a, sx, sy float64
+	for i, r := range s.Regions {
+		if rings[i], err = contours(r.Geor); err != nil {
+			err = fmt.Errorf("region %d: %v", i, err)
+			return
+		}
+		pi := integrate(rings[i])
+		a, sx, sy = a+pi.A, sx+pi.Sx, sy+pi.Sy
+	}
+	xc, yc := sy/a, sx/a
+	ts := make([][]triangle, len(s.Regions))
+	for i, r := range s.Regions {
+		mesh, err := GenerateMsh(r.Geor)
+		if err != nil {
+			return mc, fmt.Errorf("region %d: %v", i, err)
+		}
+		MoveXOY(mesh, -xc, -yc)
+		movePolygons(rings[i], -xc, -yc)
+		ts[i] = triangles(*mesh)
+	}
+	// bars with displaced law of regions
+	type bar struct {
+		x, y, area float64
+		law, host  Law
+	}
+	bars := make([]bar, len(s.Bars))
+	for i, b := range s.Bars {
+		bars[i] = bar{x: b.X - xc, y: b.Y - yc, area: b.Area(), law: b.Law}
+		for k := range rings {
+			if winding(rings[k], Point{X: bars[i].x, Y: bars[i].y}) != 0 {
+				bars[i].host = s.Regions[k].Law
+				break
+			}
+		}
+		if bars[i].host == nil {
+			err = fmt.Errorf("bar %d is outside of regions", i)
+			return
+		}
+	}
+	var (
+		ux, uy = -math.Sin(angle), math.Cos(angle)
+		// distance between extreme fibres in direction of curvature
+		dmin = math.Inf(1)
+		dmax = math.Inf(-1)
+	)
+	for _, rs := range rings {
+		for _, r := range rs {
+			for _, p := range r {
+				d := p.X*ux + p.Y*uy
+				dmin = math.Min(dmin, d)
+				dmax = math.Max(dmax, d)
+			}
+		}
+	}
+	h := dmax - dmin
+	force := func(p plane) (f StressResultant) {
+		for i, r := range s.Regions {
+			fr := stresses(ts[i], p, r.Law)
+			f.N, f.Mx, f.My = f.N+fr.N, f.Mx+fr.Mx, f.My+fr.My
+		}
+		for _, b := range bars {
+			e := p.strain(b.x, b.y)
+			v := b.area * (b.law.Stress(e) - b.host.Stress(e))
+			f.N += v
+			f.Mx += v * b.y
+			f.My += v * b.x
+		}
+		return
+	}
+	// reached return true if strain of any region or bar is out of
+	// limits of law
+	reached := func(p plane, limits func(Law) (float64, float64)) bool {
+		out := func(l Law, e float64) bool {
+			t, c := limits(l)
+			return e < t || c < e
+		}
+		for i, rs := range rings {
+			for _, r := range rs {
+				for _, pt := range r {
+					if out(s.Regions[i].Law, p.strain(pt.X, pt.Y)) {
+						return true
+					}
+				}
+			}
+		}
+		for _, b := range bars {
+			if out(b.law, p.strain(b.x, b.y)) {
+				return true
+			}
+		}
+		return false
+	}
+	// state return point in equilibrium with axial force for curvature k
+	state := func(k float64) (cp CurvaturePoint, p plane, err error) {
+		p.Kx, p.Ky = k*ux, k*uy
+		// bracket of strain at center
+		var (
+			delta  = 1e-3 + math.Abs(k)*h
+			lo, hi = -delta, delta
+			fl, fh float64
+		)
+		for iter := 0; ; iter++ {
+			p.E0 = lo
+			fl = force(p).N - n
+			p.E0 = hi
+			fh = force(p).N - n
+			if fl <= 0 && 0 <= fh {
+				break
+			}
+			if iter == IterMax {
+				err = fmt.Errorf("axial force %e is out of resistance for curvature %e", n, k)
+				return
+			}
+			lo, hi = 2*lo, 2*hi
+		}
+		// regula falsi with Illinois modification
+		var (
+			tol  = Eps * Eps * (fh - fl)
+			side int
+		)
+		p.E0 = (lo + hi) / 2
+		for iter := 0; iter < IterMax && Eps*Eps < hi-lo && fl < fh; iter++ {
+			p.E0 = hi - fh*(hi-lo)/(fh-fl)
+			f := force(p).N - n
+			if math.Abs(f) <= tol {
+				break
+			}
+			if f < 0 {
+				lo, fl = p.E0, f
+				if side < 0 {
+					fh /= 2
+				}
+				side = -1
+			} else {
+				hi, fh = p.E0, f
+				if 0 < side {
+					fl /= 2
+				}
+				side = 1
+			}
+		}
+		f := force(p)
+		cp = CurvaturePoint{
+			Curvature: k,
+			Strain:    p.E0,
+			M:         f.Mx*uy + f.My*ux,
+			Mx:        f.Mx,
+			My:        f.My,
+		}
+		return
+	}
+	ultimate := func(l Law) (float64, float64) { return l.Ultimate() }
+	yield := func(l Law) (float64, float64) { return l.Yield() }
+	// limit return the first curvature in range with reached limits
+	limit := func(lo, hi float64, limits func(Law) (float64, float64)) (float64, error) {
+		for iter := 0; iter < IterMax && Eps*Eps*hi < hi-lo; iter++ {
+			k := (lo + hi) / 2
+			_, p, err := state(k)
+			if err != nil {
+				return 0, err
+			}
+			if reached(p, limits) {
+				hi = k
+			} else {
+				lo = k
+			}
+		}
+		return (lo + hi) / 2, nil
+	}
+
+	_, p0, err := state(0)
+	if err != nil {
+		return
+	}
+	if reached(p0, ultimate) {
+		err = fmt.Errorf("axial force %e is more than resistance", n)
+		return
+	}
+	// upper bound of ultimate curvature
+	ku := Eps / h
+	for iter := 0; ; iter++ {
+		if iter == IterMax {
+			err = fmt.Errorf("ultimate strain is not reached")
+			return
+		}
+		var p plane
+		if _, p, err = state(ku); err != nil {
+			return
+		}
+		if reached(p, ultimate) {
+			break
+		}
+		ku *= 2
+	}
+	if ku, err = limit(ku/2, ku, ultimate); err != nil {
+		return
+	}
+	if mc.Ultimate, _, err = state(ku); err != nil {
+		return
+	}
+	ky := 0.0
+	if !reached(p0, yield) {
+		if ky, err = limit(0, ku, yield); err != nil {
+			return
+		}
+	}
+	if mc.Yield, _, err = state(ky); err != nil {
+		return
+	}
+	for i := 0; i <= steps; i++ {
+		var cp CurvaturePoint
+		if cp, _, err = state(ku * float64(i) / float64(steps)); err != nil {
+			return
+		}
+		mc.Curve = append(mc.Curve, cp)
+	}
+	return
+}
@@ -0,0 +1,667 @@
+package section
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Konstantin8105/msh"
+)
+
+// Delaunay refinement of planar straight line graph:
+//
+//   - all points of contours are inserted by Bowyer-Watson algorithm
+//   - segments of contours are split, until all segments is part of
+//     triangulation and not encroached
+//   - winding number of triangles is found by flooding from the super
+//     triangle, it is changed only on segments of contours
+//   - triangles with long edges or small angles are split by circumcenter
+//
+// Cavity of inserted point is not crossed segments, so winding number
+// of new triangles is same as winding number of cavity.
+//
+// See "Delaunay refinement algorithms for triangular mesh generation",
+// Jonathan Richard Shewchuk, 2002.
+
+// dtri is triangle of Delaunay triangulation
+type dtri struct {
+	v      [3]int // index of points in counterclockwise order
+	n      [3]int // index of neighbor triangle opposite point v[i], -1 if not exist
+	dead   bool   // triangle is removed
+	wind   int    // winding number of contours
+	inside bool   // triangle is inside contours
+}
+
+type delaunay struct {
+	ps      []Point
+	ts      []dtri
+	vt      []int    // index of triangle for each point
+	segs    [][2]int // segments of contours
+	segw    []int    // change of winding number from right to left side of segment
+	segMap  map[[2]int]int
+	check   []int   // segments for check
+	flooded bool    // winding numbers of triangles are found
+	size    float64 // maximal length of triangle edge
+	minLen  float64 // minimal length of segment
+	queue   []int   // triangles for check quality
+}
+
+// orient return positive value for counterclockwise points
+func orient(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// inCircle return positive value if point d inside circumcircle of
+// counterclockwise triangle abc
+func inCircle(a, b, c, d Point) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+	return (adx*adx+ady*ady)*(bdx*cdy-cdx*bdy) -
+		(bdx*bdx+bdy*bdy)*(adx*cdy-cdx*ady) +
+		(cdx*cdx+cdy*cdy)*(adx*bdy-bdx*ady)
+}
+
+// circumcenter return center of circumcircle of triangle
+func circumcenter(a, b, c Point) Point {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	return Point{
+		X: a.X + (cy*b2-by*c2)/d,
+		Y: a.Y + (bx*c2-cx*b2)/d,
+	}
+}
+
+func distance(a, b Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+// winding return winding number of point for contours
+func winding(rings [][]Point, p Point) (w int) {
+	for _, r := range rings {
+		for i := range r {
+			a, b := r[i], r[(i+1)%len(r)]
+			if a.Y <= p.Y {
+				if p.Y < b.Y && 0 < orient(a, b, p) {
+					w++
+				}
+			} else if b.Y <= p.Y && orient(a, b, p) < 0 {
+				w--
+			}
+		}
+	}
+	return
+}
+
+// triangulate return triangle mesh of region inside contours. Outer
+// contours are counterclockwise, holes are clockwise.
+// Maximal length of triangle edges is `size`.
+func triangulate(rings [][]Point, size float64) (mesh *msh.Msh, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("triangulate: %v", err)
+		}
+	}()
+	if size <= 0 {
+		err = fmt.Errorf("not valid size of triangle: %e", size)
+		return
+	}
+	d := new(delaunay)
+	d.size = size
+
+	// bounding box
+	var xmin, xmax, ymin, ymax float64
+	first := true
+	for _, r := range rings {
+		for _, p := range r {
+			if first {
+				xmin, xmax, ymin, ymax = p.X, p.X, p.Y, p.Y
+				first = false
+			}
+			xmin, xmax = math.Min(xmin, p.X), math.Max(xmax, p.X)
+			ymin, ymax = math.Min(ymin, p.Y), math.Max(ymax, p.Y)
+		}
+	}
+	if first {
+		err = fmt.Errorf("contours are empty")
+		return
+	}
+	scale := math.Max(xmax-xmin, ymax-ymin)
+	if scale <= 0 {
+		err = fmt.Errorf("contours are degenerate")
+		return
+	}
+	d.minLen = scale * 1e-6
+
+	// super triangle
+	xc, yc := (xmin+xmax)/2, (ymin+ymax)/2
+	d.ps = []Point{
+		{X: xc - 20*scale, Y: yc - 20*scale},
+		{X: xc + 20*scale, Y: yc - 20*scale},
+		{X: xc, Y: yc + 20*scale},
+	}
+	d.vt = []int{0, 0, 0}
+	d.ts = []dtri{{v: [3]int{0, 1, 2}, n: [3]int{-1, -1, -1}}}
+
+	// insert points of contours
+	for _, r := range rings {
+		var index []int
+		for _, p := range r {
+			index = append(index, d.insert(p))
+		}
+		for i := range index {
+			d.segs = append(d.segs, [2]int{index[i], index[(i+1)%len(index)]})
+		}
+	}
+	d.splitCollinear()
+	d.segMap = map[[2]int]int{}
+	segs := d.segs
+	d.segs = nil
+	for _, s := range segs {
+		d.addSegment(s[0], s[1], 1)
+	}
+
+	// refinement
+	d.queue = d.queue[:0]
+	for i := range d.ts {
+		d.queue = append(d.queue, i)
+	}
+	for iter := 0; ; iter++ {
+		if 200000 < len(d.ps) {
+			err = fmt.Errorf("too many points")
+			return
+		}
+		if d.fixSegments() {
+			continue
+		}
+		if !d.flooded {
+			d.flood()
+		}
+		if len(d.queue) == 0 {
+			break
+		}
+		t := d.queue[len(d.queue)-1]
+		d.queue = d.queue[:len(d.queue)-1]
+		if d.ts[t].dead || !d.ts[t].inside || !d.bad(t) {
+			continue
+		}
+		var (
+			v = d.ts[t].v
+			c = circumcenter(d.ps[v[0]], d.ps[v[1]], d.ps[v[2]])
+		)
+		if enc := d.encroached(c, t); 0 < len(enc) {
+			split := false
+			for _, s := range enc {
+				split = d.split(s) || split
+			}
+			if split {
+				d.queue = append(d.queue, t)
+			}
+			continue
+		}
+		d.insert(c)
+	}
+
+	// create mesh
+	mesh = new(msh.Msh)
+	id := make([]int, len(d.ps))
+	for _, t := range d.ts {
+		if t.dead || !t.inside {
+			continue
+		}
+		var el msh.Element
+		el.Id = len(mesh.Elements) + 1
+		el.EType = msh.Triangle
+		for _, v := range t.v {
+			if id[v] == 0 {
+				var n msh.Node
+				n.Id = len(mesh.Nodes) + 1
+				n.Coord[0], n.Coord[1] = d.ps[v].X, d.ps[v].Y
+				mesh.Nodes = append(mesh.Nodes, n)
+				id[v] = n.Id
+			}
+			el.NodeId = append(el.NodeId, id[v])
+		}
+		mesh.Elements = append(mesh.Elements, el)
+	}
+	if len(mesh.Elements) == 0 {
+		err = fmt.Errorf("mesh is empty")
+	}
+	return
+}
+
+// bad return true for triangle with long edges or small angles
+func (d *delaunay) bad(t int) bool {
+	var (
+		v       = d.ts[t].v
+		a, b, c = d.ps[v[0]], d.ps[v[1]], d.ps[v[2]]
+		la      = distance(b, c)
+		lb      = distance(a, c)
+		lc      = distance(a, b)
+		lmin    = math.Min(la, math.Min(lb, lc))
+		lmax    = math.Max(la, math.Max(lb, lc))
+	)
+	if d.size < lmax {
+		return true
+	}
+	// ratio circumradius to shortest edge
+	r := distance(circumcenter(a, b, c), a)
+	return math.Sqrt2 < r/lmin && d.minLen < lmin
+}
+
+// locate return triangle with point inside
+func (d *delaunay) locate(p Point) int {
+	t := len(d.ts) - 1
+	for d.ts[t].dead {
+		t--
+	}
+	for step := 0; step < len(d.ts); step++ {
+		moved := false
+		for i := 0; i < 3; i++ {
+			a := d.ps[d.ts[t].v[(i+1)%3]]
+			b := d.ps[d.ts[t].v[(i+2)%3]]
+			if orient(a, b, p) < 0 && d.ts[t].n[i] != -1 {
+				t = d.ts[t].n[i]
+				moved = true
+				break
+			}
+		}
+		if !moved {
+			return t
+		}
+	}
+	// walk is cycled - check all triangles
+	for i := range d.ts {
+		if d.ts[i].dead {
+			continue
+		}
+		inside := true
+		for k := 0; k < 3; k++ {
+			a := d.ps[d.ts[i].v[(k+1)%3]]
+			b := d.ps[d.ts[i].v[(k+2)%3]]
+			if orient(a, b, p) < 0 {
+				inside = false
+			}
+		}
+		if inside {
+			return i
+		}
+	}
+	return t
+}
+
+// insert point in triangulation and return index of point
+func (d *delaunay) insert(p Point) int {
+	t0 := d.locate(p)
+	for _, v := range d.ts[t0].v {
+		if distance(d.ps[v], p) < d.minLen {
+			return v
+		}
+	}
+	pi := len(d.ps)
+	d.ps = append(d.ps, p)
+	d.vt = append(d.vt, t0)
+
+	cavity := d.cavity(p, t0)
+	in := make(map[int]bool, len(cavity))
+	for _, t := range cavity {
+		in[t] = true
+	}
+	// boundary edges of cavity
+	type edge struct {
+		a, b  int // points
+		outer int // outer triangle
+		wind  int // winding number of cavity triangle
+	}
+	var edges []edge
+	for _, t := range cavity {
+		for i := 0; i < 3; i++ {
+			// segments of cavity is checked later
+			if s, ok := d.segMap[key(d.ts[t].v[(i+1)%3], d.ts[t].v[(i+2)%3])]; ok {
+				d.check = append(d.check, s)
+			}
+			if n := d.ts[t].n[i]; n == -1 || !in[n] {
+				edges = append(edges, edge{
+					a:     d.ts[t].v[(i+1)%3],
+					b:     d.ts[t].v[(i+2)%3],
+					outer: n,
+					wind:  d.ts[t].wind,
+				})
+			}
+		}
+		d.ts[t].dead = true
+	}
+	// new triangles
+	var (
+		byStart = map[int]int{}
+		byEnd   = map[int]int{}
+		created = make([]int, len(edges))
+	)
+	for k, e := range edges {
+		var t int
+		if k < len(cavity) {
+			t = cavity[k]
+		} else {
+			t = len(d.ts)
+			d.ts = append(d.ts, dtri{})
+		}
+		created[k] = t
+		d.ts[t] = dtri{
+			v:    [3]int{e.a, e.b, pi},
+			n:    [3]int{-1, -1, e.outer},
+			wind: e.wind,
+		}
+		if e.outer != -1 {
+			for i := range d.ts[e.outer].n {
+				o := d.ts[e.outer].v
+				if o[(i+1)%3] == e.b && o[(i+2)%3] == e.a {
+					d.ts[e.outer].n[i] = t
+				}
+			}
+		}
+		byStart[e.a] = t
+		byEnd[e.b] = t
+		for _, v := range d.ts[t].v {
+			d.vt[v] = t
+		}
+	}
+	for _, t := range created {
+		v := d.ts[t].v
+		// opposite v[0] is edge (v[1], p)
+		d.ts[t].n[0] = byStart[v[1]]
+		// opposite v[1] is edge (p, v[0])
+		d.ts[t].n[1] = byEnd[v[0]]
+	}
+	for _, t := range created {
+		d.ts[t].inside = d.isInside(t)
+		d.queue = append(d.queue, t)
+	}
+	return pi
+}
+
+// cavity return triangles with point inside circumcircle, that are
+// connected with triangle `t0` without crossing segments
+func (d *delaunay) cavity(p Point, t0 int) (cavity []int) {
+	cavity = []int{t0}
+	in := map[int]bool{t0: true}
+	for k := 0; k < len(cavity); k++ {
+		t := cavity[k]
+		for i, n := range d.ts[t].n {
+			if n == -1 || in[n] {
+				continue
+			}
+			if _, ok := d.segMap[key(d.ts[t].v[(i+1)%3], d.ts[t].v[(i+2)%3])]; ok {
+				continue
+			}
+			v := d.ts[n].v
+			if 0 < inCircle(d.ps[v[0]], d.ps[v[1]], d.ps[v[2]], p) {
+				in[n] = true
+				cavity = append(cavity, n)
+			}
+		}
+	}
+	return
+}
+
+// isInside return true if triangle is inside contours
+func (d *delaunay) isInside(t int) bool {
+	if !d.flooded {
+		return false
+	}
+	for _, p := range d.ts[t].v {
+		if p < 3 {
+			// point of super triangle
+			return false
+		}
+	}
+	return 0 < d.ts[t].wind
+}
+
+// flood find winding number of all triangles from triangles of super
+// triangle. All segments must be part of triangulation.
+func (d *delaunay) flood() {
+	var (
+		visited = make([]bool, len(d.ts))
+		stack   []int
+	)
+	for t := range d.ts {
+		if d.ts[t].dead {
+			continue
+		}
+		v := d.ts[t].v
+		if v[0] < 3 || v[1] < 3 || v[2] < 3 {
+			d.ts[t].wind = 0
+			visited[t] = true
+			stack = append(stack, t)
+		}
+	}
+	for len(stack) != 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for i, n := range d.ts[t].n {
+			if n == -1 || visited[n] {
+				continue
+			}
+			// triangle is on the left side of edge (a,b)
+			a, b := d.ts[t].v[(i+1)%3], d.ts[t].v[(i+2)%3]
+			w := d.ts[t].wind
+			if s, ok := d.segMap[key(a, b)]; ok {
+				if d.segs[s][0] == a {
+					w -= d.segw[s]
+				} else {
+					w += d.segw[s]
+				}
+			}
+			d.ts[n].wind = w
+			visited[n] = true
+			stack = append(stack, n)
+		}
+	}
+	d.flooded = true
+	for t := range d.ts {
+		if !d.ts[t].dead {
+			d.ts[t].inside = d.isInside(t)
+		}
+	}
+}
+
+// contains return true if point is inside triangle or on edge
+func (d *delaunay) contains(t int, p Point) bool {
+	v := d.ts[t].v
+	for i := 0; i < 3; i++ {
+		if orient(d.ps[v[(i+1)%3]], d.ps[v[(i+2)%3]], p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// around return triangles around point
+func (d *delaunay) around(p int) (ts []int) {
+	start := d.vt[p]
+	if d.ts[start].dead {
+		// find any alive triangle
+		for i := range d.ts {
+			if d.ts[i].dead {
+				continue
+			}
+			v := d.ts[i].v
+			if v[0] == p || v[1] == p || v[2] == p {
+				start = i
+				break
+			}
+		}
+		d.vt[p] = start
+	}
+	visited := func(t int) bool {
+		for _, v := range ts {
+			if v == t {
+				return true
+			}
+		}
+		return false
+	}
+	stack := []int{start}
+	for len(stack) != 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited(t) {
+			continue
+		}
+		ts = append(ts, t)
+		for i := 0; i < 3; i++ {
+			n := d.ts[t].n[i]
+			if n == -1 || d.ts[t].v[i] == p || visited(n) {
+				continue
+			}
+			stack = append(stack, n)
+		}
+	}
+	return
+}
+
+// edge return apexes of triangles with edge (a,b).
+// Edge is not exist, if result is empty.
+func (d *delaunay) edge(a, b int) (apex []int) {
+	for _, t := range d.around(a) {
+		v := d.ts[t].v
+		if v[0] == b || v[1] == b || v[2] == b {
+			apex = append(apex, v[0]+v[1]+v[2]-a-b)
+		}
+	}
+	return
+}
+
+// encroach return true if point is inside of diametral circle of segment
+func (d *delaunay) encroach(s int, p Point) bool {
+	a, b := d.ps[d.segs[s][0]], d.ps[d.segs[s][1]]
+	return (a.X-p.X)*(b.X-p.X)+(a.Y-p.Y)*(b.Y-p.Y) < 0
+}
+
+// encroached return list of segments encroached by point `p`, that is
+// circumcenter of triangle `t`. Only segments on boundary of cavity of
+// point are checked, all segments are checked if point is out of cavity.
+func (d *delaunay) encroached(p Point, t int) (list []int) {
+	var (
+		found = map[int]bool{}
+		in    bool
+	)
+	for _, c := range d.cavity(p, t) {
+		v := d.ts[c].v
+		for i := 0; i < 3; i++ {
+			s, ok := d.segMap[key(v[(i+1)%3], v[(i+2)%3])]
+			if ok && !found[s] && d.encroach(s, p) {
+				found[s] = true
+				list = append(list, s)
+			}
+		}
+		in = in || d.contains(c, p)
+	}
+	if in {
+		return
+	}
+	list = list[:0]
+	for s := range d.segs {
+		if d.encroach(s, p) {
+			list = append(list, s)
+		}
+	}
+	return
+}
+
+// split segment in middle point. Return false if segment is too short.
+func (d *delaunay) split(s int) bool {
+	a, b := d.segs[s][0], d.segs[s][1]
+	if distance(d.ps[a], d.ps[b]) < 2*d.minLen {
+		return false
+	}
+	// cavity of middle point is on both sides of segment
+	delete(d.segMap, key(a, b))
+	m := d.insert(Point{
+		X: (d.ps[a].X + d.ps[b].X) / 2.0,
+		Y: (d.ps[a].Y + d.ps[b].Y) / 2.0,
+	})
+	if m == a || m == b {
+		d.segMap[key(a, b)] = s
+		return false
+	}
+	d.segs[s] = [2]int{a, m}
+	d.segMap[key(a, m)] = s
+	d.check = append(d.check, s)
+	d.addSegment(m, b, d.segw[s])
+	return true
+}
+
+// key return key of segment for map
+func key(a, b int) [2]int {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// addSegment add segment with change of winding number `w` from right
+// to left side. For existing segment change of winding number is added.
+func (d *delaunay) addSegment(a, b, w int) {
+	if a == b {
+		return
+	}
+	if s, ok := d.segMap[key(a, b)]; ok {
+		if d.segs[s][0] == a {
+			d.segw[s] += w
+		} else {
+			d.segw[s] -= w
+		}
+		return
+	}
+	d.segMap[key(a, b)] = len(d.segs)
+	d.check = append(d.check, len(d.segs))
+	d.segs = append(d.segs, [2]int{a, b})
+	d.segw = append(d.segw, w)
+}
+
+// fixSegments split missing and encroached segments from list for
+// check. Return true if any segment is split.
+func (d *delaunay) fixSegments() (split bool) {
+	check := d.check
+	d.check = nil
+	for _, s := range check {
+		a, b := d.segs[s][0], d.segs[s][1]
+		apex := d.edge(a, b)
+		bad := len(apex) == 0
+		for _, p := range apex {
+			if 3 <= p && d.encroach(s, d.ps[p]) {
+				bad = true
+			}
+		}
+		if bad && d.split(s) {
+			split = true
+		}
+	}
+	return
+}
+
+// splitCollinear split segments by points located on segments
+func (d *delaunay) splitCollinear() {
+	for s := 0; s < len(d.segs); s++ {
+		a, b := d.ps[d.segs[s][0]], d.ps[d.segs[s][1]]
+		l := distance(a, b)
+		for p := 3; p < len(d.ps); p++ {
+			if p == d.segs[s][0] || p == d.segs[s][1] {
+				continue
+			}
+			c := d.ps[p]
+			if d.minLen*l < math.Abs(orient(a, b, c)) {
+				continue
+			}
+			if t := ((c.X-a.X)*(b.X-a.X) + (c.Y-a.Y)*(b.Y-a.Y)) / (l * l); t <= 0 || 1 <= t {
+				continue
+			}
+			d.segs = append(d.segs, [2]int{p, d.segs[s][1]})
+			d.segs[s][1] = p
+			s--
+			break
+		}
+	}
+}
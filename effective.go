@@ -0,0 +1,352 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+)
+
+// EffectiveElement is effective width of compression part by EN 1993-1-5
+// section 4.4. Stress ratio Psi, buckling factor K, plate slenderness
+// Lambda and reduction factor Rho are for stress distribution of gross
+// section for flanges and of effective section for webs, see
+// EN 1993-1-5 4.4(3). Element is web if gradient of
+// stresses is mainly along element. Ineffective strip is located between
+// distances D1 and D2 from edge Begin of element.
+type EffectiveElement struct {
+	Element
+	Web                 bool
+	Psi, K, Lambda, Rho float64
+	Beff                float64 // effective width of compression zone
+	D1, D2              float64
+}
+
+// EffectiveProperty is property of effective section by EN 1993-1-5.
+// Section is PlateGroup without ineffective strips. Weff is minimal
+// elastic modulus of effective section around axe X, around axe Y for
+// force with bending only around axe Y. ENx, ENy is shift of center of
+// effective section from center of gross section.
+type EffectiveProperty struct {
+	Forces   Forces
+	Fy       float64
+	Section  PlateGroup
+	Property Property
+	Aeff     float64
+	Weff     float64
+	ENx, ENy float64
+	Elements []EffectiveElement
+}
+
+func (ef EffectiveProperty) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Effective section by EN 1993-1-5\n")
+	fmt.Fprintf(w, "N\t%s\taxial force, tension is positive\n", efmt.Sprint(ef.Forces.N))
+	fmt.Fprintf(w, "Mx\t%s\tmoment around axe X\n", efmt.Sprint(ef.Forces.Mx))
+	fmt.Fprintf(w, "My\t%s\tmoment around axe Y\n", efmt.Sprint(ef.Forces.My))
+	fmt.Fprintf(w, "fy\t%s\tyield strength\n", efmt.Sprint(ef.Fy))
+	fmt.Fprintf(w, "Aeff\t%s\teffective area\n", efmt.Sprint(ef.Aeff))
+	fmt.Fprintf(w, "Weff\t%s\tminimal effective elastic modulus\n", efmt.Sprint(ef.Weff))
+	fmt.Fprintf(w, "ENx\t%s\tshift of center by axe X\n", efmt.Sprint(ef.ENx))
+	fmt.Fprintf(w, "ENy\t%s\tshift of center by axe Y\n", efmt.Sprint(ef.ENy))
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Name\tKind\tWeb\tc\tt\tPsi\tK\tLambda\tRho\tBeff\tD1\tD2\n")
+	for _, e := range ef.Elements {
+		fmt.Fprintf(w, "%s\t%s\t%v\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Name, e.Kind, e.Web,
+			efmt.Sprint(e.C), efmt.Sprint(e.T),
+			efmt.Sprint(e.Psi), efmt.Sprint(e.K),
+			efmt.Sprint(e.Lambda), efmt.Sprint(e.Rho),
+			efmt.Sprint(e.Beff), efmt.Sprint(e.D1), efmt.Sprint(e.D2),
+		)
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// Effective return effective section of PlateGroup for load and yield
+// strength fy in Pa, see EffectiveForce.
+func Effective(pg PlateGroup, load Load, fy float64) (ef EffectiveProperty, err error) {
+	var f Forces
+	switch load {
+	case Compression:
+		f.N = -1
+	case BendingX:
+		f.Mx = -1
+	case BendingY:
+		f.My = -1
+	default:
+		err = fmt.Errorf("undefined load: %v", load)
+		return
+	}
+	return EffectiveForce(pg, f, fy)
+}
+
+// EffectiveForce return effective section of PlateGroup for internal
+// forces f and yield strength fy in Pa. Sign convention of forces is the
+// same as for Stresses, shear forces and torque are ignored. Axial force
+// is applied at center of section, compression stresses are linear:
+//
+//	stress = -N/A - ((My*Jxx - Mx*Jxy)*x + (Mx*Jyy - My*Jxy)*y) / (Jxx*Jyy - Jxy^2)
+//
+// Maximal compression stress in elements is fy, so only ratios of forces
+// are important. Webs are cut by stresses of section with effective
+// flanges and gross webs, then neutral axe is iterated: webs are cut
+// again by stresses of effective section until center of effective
+// section is not changed. Property of effective section is calculated
+// by Calculate.
+func EffectiveForce(pg PlateGroup, f Forces, fy float64) (ef EffectiveProperty, err error) {
+	if fy <= 0 {
+		err = fmt.Errorf("yield strength is not positive: %e", fy)
+		return
+	}
+	if f.N == 0 && f.Mx == 0 && f.My == 0 {
+		err = fmt.Errorf("zero internal force")
+		return
+	}
+	rings, err := contours(pg)
+	if err != nil {
+		return
+	}
+	// stress return linear compression stresses of forces f for section
+	// contours
+	stress := func(rings [][]Point) func(p Point) float64 {
+		pi := integrate(rings)
+		var (
+			xc  = pi.Sy / pi.A
+			yc  = pi.Sx / pi.A
+			jxx = pi.Jxx - pi.A*yc*yc
+			jyy = pi.Jyy - pi.A*xc*xc
+			jxy = pi.Jxy - pi.A*xc*yc
+			det = jxx*jyy - jxy*jxy
+			kx  = (f.My*jxx - f.Mx*jxy) / det
+			ky  = (f.Mx*jyy - f.My*jxy) / det
+		)
+		return func(p Point) float64 {
+			return -(f.N/pi.A + kx*(p.X-xc) + ky*(p.Y-yc))
+		}
+	}
+	var (
+		pi    = integrate(rings)
+		xg    = pi.Sy / pi.A
+		yg    = pi.Sx / pi.A
+		size  float64
+		eps   = math.Sqrt(235e6 / fy)
+		es    = pg.plateElements()
+		gross = stress(rings)
+		// gradient of stresses
+		gx = gross(Point{X: 1}) - gross(Point{})
+		gy = gross(Point{Y: 1}) - gross(Point{})
+		// maximal stress on edges of elements
+		smax float64
+	)
+	for _, e := range es {
+		smax = math.Max(smax, math.Max(math.Abs(gross(e.Begin)), math.Abs(gross(e.End))))
+	}
+	for _, r := range rings {
+		for _, p := range r {
+			size = math.Max(size, math.Max(math.Abs(p.X-xg), math.Abs(p.Y-yg)))
+		}
+	}
+	ef.Forces = f
+	ef.Fy = fy
+	ef.Elements = make([]EffectiveElement, len(es))
+	cuts := make([][][2]float64, len(pg.Plates))
+	calc := func(i int, stress func(Point) float64) {
+		e := es[i]
+		sb, se := stress(e.Begin), stress(e.End)
+		if math.Abs(sb) <= Eps*smax {
+			sb = 0
+		}
+		if math.Abs(se) <= Eps*smax {
+			se = 0
+		}
+		if math.Abs(sb-se) <= Eps*smax {
+			se = sb
+		}
+		ee := effective(e.Element, eps, sb, se)
+		ee.Web = ef.Elements[i].Web
+		ef.Elements[i] = ee
+		if ee.D2-ee.D1 <= Eps*e.C {
+			return
+		}
+		s1 := e.S + (e.E-e.S)*ee.D1/e.C
+		s2 := e.S + (e.E-e.S)*ee.D2/e.C
+		cuts[e.Plate] = append(cuts[e.Plate],
+			[2]float64{math.Min(s1, s2), math.Max(s1, s2)})
+	}
+	// flanges by stresses of gross section
+	for i, e := range es {
+		var (
+			dx, dy = e.End.X - e.Begin.X, e.End.Y - e.Begin.Y
+			along  = math.Abs(gx*dx + gy*dy)
+			across = math.Abs(-gx*dy + gy*dx)
+		)
+		if ef.Elements[i].Web = across < along; !ef.Elements[i].Web {
+			calc(i, gross)
+		}
+	}
+	// webs by stresses of effective section, iteration is started from
+	// section with effective flanges and gross webs
+	var (
+		flanges = cuts
+		iter    int
+	)
+	ef.Section = cutPlates(pg, flanges)
+	pe := integrate(ef.Section.Polygons())
+	xc, yc := pe.Sy/pe.A, pe.Sx/pe.A
+	for iter = 0; iter < IterMax; iter++ {
+		webs := stress(ef.Section.Polygons())
+		cuts = make([][][2]float64, len(pg.Plates))
+		for i := range flanges {
+			cuts[i] = append(cuts[i], flanges[i]...)
+		}
+		for i := range es {
+			if ef.Elements[i].Web {
+				calc(i, webs)
+			}
+		}
+		ef.Section = cutPlates(pg, cuts)
+		pe = integrate(ef.Section.Polygons())
+		x, y := pe.Sy/pe.A, pe.Sx/pe.A
+		converged := math.Abs(x-xc) <= Eps*size && math.Abs(y-yc) <= Eps*size
+		xc, yc = x, y
+		if converged {
+			break
+		}
+	}
+	if iter == IterMax {
+		err = fmt.Errorf("center of effective section is not converged")
+		return
+	}
+	p, err := Calculate(ef.Section)
+	if err != nil {
+		return
+	}
+	ef.Property = *p
+	ef.Aeff = p.A
+	ef.ENx, ef.ENy = p.X-xg, p.Y-yg
+	ef.Weff = p.AtCenterPoint.Wx
+	if f.Mx == 0 && f.My != 0 {
+		ef.Weff = p.AtCenterPoint.Wy
+	}
+	return
+}
+
+// effective return effective width of element by EN 1993-1-5 Table 4.1
+// and Table 4.2 for stresses on edges of element, compression is
+// positive
+func effective(e Element, eps, sb, se float64) (ee EffectiveElement) {
+	ee.Element = e
+	ee.Rho = 1
+	ee.Beff = e.C
+	if math.Max(sb, se) <= 0 {
+		// element in tension
+		return
+	}
+	var (
+		c = e.C
+		// distances of ineffective strip from edge with maximal
+		// compression
+		d1, d2 float64
+		// swap is true for maximal compression on End
+		swap = sb < se
+	)
+	if swap {
+		ee.Psi = sb / se
+	} else {
+		ee.Psi = se / sb
+	}
+	psi := ee.Psi
+	// width of compression zone
+	bc := c
+	if psi < 0 {
+		bc = c / (1 - psi)
+	}
+	switch e.Kind {
+	case Internal:
+		ee.K = internalFactor(psi)
+		ee.Lambda = c / e.T / (28.4 * eps * math.Sqrt(ee.K))
+		if l := ee.Lambda; 0.5+math.Sqrt(0.085-0.055*psi) < l {
+			ee.Rho = math.Min(1, (l-0.055*(3+psi))/(l*l))
+		}
+		ee.Beff = ee.Rho * bc
+		be1 := 0.4 * ee.Beff
+		if 0 <= psi {
+			be1 = 2 * ee.Beff / (5 - psi)
+		}
+		d1, d2 = be1, bc-(ee.Beff-be1)
+
+	case Outstand:
+		ee.K = outstandFactor(psi, swap)
+		ee.Lambda = c / e.T / (28.4 * eps * math.Sqrt(ee.K))
+		if l := ee.Lambda; 0.748 < l {
+			ee.Rho = math.Min(1, (l-0.188)/(l*l))
+		}
+		ee.Beff = ee.Rho * bc
+		// effective zone is near supported edge
+		if swap {
+			// from tip
+			d1, d2 = 0, bc-ee.Beff
+		} else {
+			d1, d2 = ee.Beff, bc
+		}
+
+	default:
+		return
+	}
+	if ee.Rho == 1 {
+		return
+	}
+	if swap {
+		d1, d2 = c-d2, c-d1
+	}
+	ee.D1, ee.D2 = d1, d2
+	return
+}
+
+// cutPlates return PlateGroup without strips cuts[i] along long side of
+// plates i
+func cutPlates(pg PlateGroup, cuts [][][2]float64) (res PlateGroup) {
+	res.Name = pg.Name
+	if res.Name == "" {
+		res.Name = "Plate group"
+	}
+	res.Name += ", effective"
+	for i, p := range pg.Plates {
+		if len(cuts[i]) == 0 {
+			res.Plates = append(res.Plates, p)
+			continue
+		}
+		long := p.Y <= p.X
+		a, b := p.Yc-p.Y/2, p.Yc+p.Y/2
+		if long {
+			a, b = p.Xc-p.X/2, p.Xc+p.X/2
+		}
+		cs := cuts[i]
+		sort.Slice(cs, func(i, j int) bool { return cs[i][0] < cs[j][0] })
+		add := func(from, to float64) {
+			if to-from <= Eps*(b-a) {
+				return
+			}
+			q := p
+			if long {
+				q.Xc, q.X = (from+to)/2, to-from
+			} else {
+				q.Yc, q.Y = (from+to)/2, to-from
+			}
+			res.Plates = append(res.Plates, q)
+		}
+		for _, c := range cs {
+			add(a, c[0])
+			a = math.Max(a, c[1])
+		}
+		add(a, b)
+	}
+	return
+}
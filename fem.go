@@ -0,0 +1,281 @@
+package section
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Konstantin8105/msh"
+)
+
+// Finite element helpers for scalar problems on the triangle mesh:
+//
+//	div(grad(u)) = f
+//
+// Used linear triangle elements with 3 nodes:
+//
+//	N1 = (a1 + b1*x + c1*y) / (2*A)
+//	b1 = y2 - y3
+//	c1 = x3 - x2
+//
+// Gradient of shape functions is constant inside triangle.
+
+// triangle is linear finite element of mesh
+type triangle struct {
+	index [3]int     // index of nodes in mesh.Nodes
+	x, y  [3]float64 // coordinates of nodes
+	area  float64    // area of triangle
+	dx    [3]float64 // derivative dN/dx
+	dy    [3]float64 // derivative dN/dy
+}
+
+// center return center of mass of triangle
+func (t triangle) center() (xc, yc float64) {
+	xc = (t.x[0] + t.x[1] + t.x[2]) / 3.0
+	yc = (t.y[0] + t.y[1] + t.y[2]) / 3.0
+	return
+}
+
+// gradient return gradient of linear field with values in nodes
+func (t triangle) gradient(u []float64) (ux, uy float64) {
+	for i := range t.index {
+		ux += t.dx[i] * u[t.index[i]]
+		uy += t.dy[i] * u[t.index[i]]
+	}
+	return
+}
+
+// integral return integral(f*g,dA) for linear fields with values in nodes
+//
+//	integral(f*g,dA) = A/12 * (sum(fi*gi) + sum(fi)*sum(gi))
+func (t triangle) integral(f, g [3]float64) float64 {
+	var fg, sf, sg float64
+	for i := range f {
+		fg += f[i] * g[i]
+		sf += f[i]
+		sg += g[i]
+	}
+	return t.area / 12.0 * (fg + sf*sg)
+}
+
+// triangles return all triangles of mesh
+func triangles(mesh msh.Msh) (ts []triangle) {
+	for i := range mesh.Elements {
+		if mesh.Elements[i].EType != msh.Triangle {
+			continue
+		}
+		var (
+			t  triangle
+			ns = mesh.Elements[i].NodeId
+		)
+		for k := range t.index {
+			t.index[k] = mesh.GetNode(ns[k])
+			t.x[k] = mesh.Nodes[t.index[k]].Coord[0]
+			t.y[k] = mesh.Nodes[t.index[k]].Coord[1]
+		}
+		det := (t.x[1]-t.x[0])*(t.y[2]-t.y[0]) - (t.x[2]-t.x[0])*(t.y[1]-t.y[0])
+		if det == 0 {
+			continue
+		}
+		t.area = math.Abs(det) / 2.0
+		for k := range t.index {
+			j, m := (k+1)%3, (k+2)%3
+			t.dx[k] = (t.y[j] - t.y[m]) / det
+			t.dy[k] = (t.x[m] - t.x[j]) / det
+		}
+		ts = append(ts, t)
+	}
+	return
+}
+
+// sparse is symmetric sparse matrix in compressed row format
+type sparse struct {
+	rows [][]int     // column indexes for each row
+	vals [][]float64 // values for each row
+}
+
+func newSparse(size int) *sparse {
+	return &sparse{
+		rows: make([][]int, size),
+		vals: make([][]float64, size),
+	}
+}
+
+// add value to matrix position (r,c)
+func (s *sparse) add(r, c int, v float64) {
+	row := s.rows[r]
+	pos := sort.SearchInts(row, c)
+	if pos < len(row) && row[pos] == c {
+		s.vals[r][pos] += v
+		return
+	}
+	s.rows[r] = append(row, 0)
+	copy(s.rows[r][pos+1:], s.rows[r][pos:])
+	s.rows[r][pos] = c
+	s.vals[r] = append(s.vals[r], 0)
+	copy(s.vals[r][pos+1:], s.vals[r][pos:])
+	s.vals[r][pos] = v
+}
+
+// diagonal return value of diagonal
+func (s *sparse) diagonal(r int) float64 {
+	row := s.rows[r]
+	pos := sort.SearchInts(row, r)
+	if pos < len(row) && row[pos] == r {
+		return s.vals[r][pos]
+	}
+	return 0
+}
+
+// fix set value of dof `r` to zero
+func (s *sparse) fix(r int) {
+	for k, c := range s.rows[r] {
+		if c == r {
+			s.vals[r][k] = 1
+			continue
+		}
+		s.vals[r][k] = 0
+		// symmetric position
+		row := s.rows[c]
+		pos := sort.SearchInts(row, r)
+		if pos < len(row) && row[pos] == r {
+			s.vals[c][pos] = 0
+		}
+	}
+	if s.diagonal(r) == 0 {
+		s.add(r, r, 1)
+	}
+}
+
+// multiply return result of matrix-vector multiplication
+func (s *sparse) multiply(x, res []float64) {
+	for r := range s.rows {
+		var v float64
+		for k, c := range s.rows[r] {
+			v += s.vals[r][k] * x[c]
+		}
+		res[r] = v
+	}
+}
+
+// solve linear system by preconditioned conjugate gradient method
+func (s *sparse) solve(b []float64) (x []float64, err error) {
+	var (
+		size = len(b)
+		r    = make([]float64, size)
+		z    = make([]float64, size)
+		p    = make([]float64, size)
+		ap   = make([]float64, size)
+		m    = make([]float64, size) // Jacobi preconditioner
+	)
+	x = make([]float64, size)
+	dot := func(a, b []float64) (v float64) {
+		for i := range a {
+			v += a[i] * b[i]
+		}
+		return
+	}
+	for i := range m {
+		d := s.diagonal(i)
+		if d == 0 {
+			err = fmt.Errorf("zero diagonal in row %d", i)
+			return
+		}
+		m[i] = 1.0 / d
+	}
+	copy(r, b)
+	norm := math.Sqrt(dot(b, b))
+	if norm == 0 {
+		return
+	}
+	for i := range z {
+		z[i] = m[i] * r[i]
+	}
+	copy(p, z)
+	rz := dot(r, z)
+	for iter := 0; iter < 10*size+IterMax; iter++ {
+		s.multiply(p, ap)
+		alpha := rz / dot(p, ap)
+		for i := range x {
+			x[i] += alpha * p[i]
+			r[i] -= alpha * ap[i]
+		}
+		if math.Sqrt(dot(r, r)) < 1e-12*norm {
+			return
+		}
+		for i := range z {
+			z[i] = m[i] * r[i]
+		}
+		rzNew := dot(r, z)
+		beta := rzNew / rz
+		rz = rzNew
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+	err = fmt.Errorf("conjugate gradient is not converged")
+	return
+}
+
+// laplace return stiffness matrix of Laplace operator integral(grad(N)*grad(N),dA).
+// For each connected part of mesh the first node is fixed, nodes without
+// triangles are fixed too.
+func laplace(mesh msh.Msh, ts []triangle) (k *sparse, fix []int) {
+	k = stiffness(mesh, ts)
+	fix = fixed(len(mesh.Nodes), ts)
+	for _, n := range fix {
+		k.fix(n)
+	}
+	return
+}
+
+// stiffness return matrix integral(grad(N)*grad(N),dA) without
+// boundary conditions
+func stiffness(mesh msh.Msh, ts []triangle) (k *sparse) {
+	k = newSparse(len(mesh.Nodes))
+	for _, t := range ts {
+		for i := range t.index {
+			for j := range t.index {
+				k.add(t.index[i], t.index[j],
+					t.area*(t.dx[i]*t.dx[j]+t.dy[i]*t.dy[j]))
+			}
+		}
+	}
+	return
+}
+
+// fixed return list of nodes for avoid singularity of Laplace matrix
+func fixed(size int, ts []triangle) (nodes []int) {
+	// union-find for connected parts of mesh
+	parent := make([]int, size)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	used := make([]bool, size)
+	for _, t := range ts {
+		for i := range t.index {
+			used[t.index[i]] = true
+			a, b := find(t.index[0]), find(t.index[i])
+			parent[a] = b
+		}
+	}
+	root := make([]bool, size)
+	for i := 0; i < size; i++ {
+		if !used[i] {
+			nodes = append(nodes, i)
+			continue
+		}
+		if r := find(i); !root[r] {
+			root[r] = true
+			nodes = append(nodes, i)
+		}
+	}
+	return
+}
@@ -0,0 +1,248 @@
+package section
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// FibreOptions is options of fibre section.
+//
+// Fibres are in coordinate system Axes. Center of MultiMaterial is
+// elastic center and angle of SectionAxe is principal angle of
+// transformed section, see CalculateMaterial, so axial force do not
+// bend elastic section.
+//
+// Material tag of fibres is Material. Fibres of region i of
+// MultiMaterial have tag Material+i, bars of RC have tag Material+1.
+// Concrete displaced by bar of RC is fibre with tag Material and negative
+// area at center of bar.
+//
+// Fibres with area less MinArea are lumped with nearest fibres of the
+// same material, area and first moment of area of fibres are kept.
+type FibreOptions struct {
+	Axes     Axes
+	Material int
+	MinArea  float64
+}
+
+// FibreArea is fibre with center (Y,Z) in local coordinates of fibre
+// section:
+//
+//	BasePoint:   Y = Y, Z = X
+//	CenterPoint: Y = Y - Yc, Z = X - Xc
+//	SectionAxe:  Y, Z are rotated on angle Alpha from CenterPoint
+//
+// so bending around axe X of section is bending around local axe Z.
+type FibreArea struct {
+	Y        float64 `json:"y"`
+	Z        float64 `json:"z"`
+	Area     float64 `json:"area"`
+	Material int     `json:"material"`
+}
+
+// FibreSection is section from fibres. Origin of local coordinates is
+// located at (X,Y), Alpha is angle from axe X to local axe Z.
+type FibreSection struct {
+	Name   string      `json:"name"`
+	X      float64     `json:"x"`
+	Y      float64     `json:"y"`
+	Alpha  float64     `json:"alpha"`
+	It     float64     `json:"it"` // torsion constant
+	Fibres []FibreArea `json:"fibres"`
+}
+
+// Fibres return fibre section with fibres at centers of triangles of
+// section mesh
+func Fibres(g Geor, o FibreOptions) (fs FibreSection, err error) {
+	if o.Axes != BasePoint && o.Axes != CenterPoint && o.Axes != SectionAxe {
+		err = fmt.Errorf("not valid axes: %d", o.Axes)
+		return
+	}
+	p, mesh, err := CalculateWithMesh(g, CenterPoint)
+	if err != nil {
+		return
+	}
+	fs.Name = p.Name
+	fs.It = p.Torsion.It
+	xo, yo, alpha := p.X, p.Y, p.Alpha
+	if m, ok := g.(MultiMaterial); ok {
+		var mp MaterialProperty
+		if mp, err = CalculateMaterial(m, m.Regions[0].Material.E); err != nil {
+			return
+		}
+		xo, yo = mp.X, mp.Y
+		alpha = (&BendingProperty{Jxx: mp.Jxx, Jyy: mp.Jyy, Jxy: mp.Jxy}).Alpha()
+	}
+	switch o.Axes {
+	case BasePoint:
+		xo, yo, alpha = 0, 0, 0
+	case CenterPoint:
+		alpha = 0
+	}
+	fs.X, fs.Y, fs.Alpha = xo, yo, alpha
+	var (
+		sin, cos = math.Sin(fs.Alpha), math.Cos(fs.Alpha)
+		// local coordinates of point in base coordinates
+		local = func(x, y float64) (ly, lz float64) {
+			x, y = x-fs.X, y-fs.Y
+			return -x*sin + y*cos, x*cos + y*sin
+		}
+		// material of point in base coordinates
+		material = func(Point) int { return o.Material }
+		bars     []FibreArea
+	)
+	switch v := g.(type) {
+	case MultiMaterial:
+		rings := make([][][]Point, len(v.Regions))
+		for i, r := range v.Regions {
+			if rings[i], err = contours(r.Geor); err != nil {
+				return
+			}
+		}
+		material = func(pt Point) int {
+			for i := range rings {
+				if winding(rings[i], pt) != 0 {
+					return o.Material + i
+				}
+			}
+			return o.Material
+		}
+	case RC:
+		for _, b := range v.Bars {
+			y, z := local(b.X, b.Y)
+			bars = append(bars,
+				FibreArea{Y: y, Z: z, Area: b.Area(), Material: o.Material + 1},
+				FibreArea{Y: y, Z: z, Area: -b.Area(), Material: o.Material},
+			)
+		}
+	}
+	for _, t := range triangles(*mesh) {
+		xc, yc := t.center()
+		y, z := local(xc+p.X, yc+p.Y)
+		fs.Fibres = append(fs.Fibres, FibreArea{
+			Y:        y,
+			Z:        z,
+			Area:     t.area,
+			Material: material(Point{X: xc + p.X, Y: yc + p.Y}),
+		})
+	}
+	fs.Fibres = lump(fs.Fibres, o.MinArea)
+	fs.Fibres = append(fs.Fibres, bars...)
+	return
+}
+
+// lump return fibres with small fibres lumped to nearest fibres of the
+// same material. Fibres are lumped in order of increasing area.
+func lump(fs []FibreArea, min float64) (res []FibreArea) {
+	var (
+		order = make([]int, len(fs))
+		alive = make([]bool, len(fs))
+	)
+	for i := range fs {
+		order[i] = i
+		alive[i] = true
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return fs[order[i]].Area < fs[order[j]].Area
+	})
+	initial := fs
+	fs = append([]FibreArea{}, fs...)
+	for _, s := range order {
+		if min <= initial[s].Area {
+			// area of fibres is only increased by lumping
+			break
+		}
+		if min <= fs[s].Area {
+			continue
+		}
+		// nearest fibre
+		n := -1
+		var dist float64
+		for i := range fs {
+			if i == s || !alive[i] || fs[i].Material != fs[s].Material {
+				continue
+			}
+			d := math.Hypot(fs[i].Y-fs[s].Y, fs[i].Z-fs[s].Z)
+			if n < 0 || d < dist {
+				n, dist = i, d
+			}
+		}
+		if n < 0 {
+			// last fibre of material
+			continue
+		}
+		a, b := fs[s], fs[n]
+		area := a.Area + b.Area
+		fs[n].Y = (a.Y*a.Area + b.Y*b.Area) / area
+		fs[n].Z = (a.Z*a.Area + b.Z*b.Area) / area
+		fs[n].Area = area
+		alive[s] = false
+	}
+	// fibres by materials in order of appearance
+	var tags []int
+	groups := map[int][]FibreArea{}
+	for i, f := range fs {
+		if !alive[i] {
+			continue
+		}
+		if _, ok := groups[f.Material]; !ok {
+			tags = append(tags, f.Material)
+		}
+		groups[f.Material] = append(groups[f.Material], f)
+	}
+	for _, tag := range tags {
+		res = append(res, groups[tag]...)
+	}
+	return
+}
+
+// Tcl return fibre section for OpenSees in Tcl. Torsional stiffness gj
+// is ignored for not positive value.
+func (fs FibreSection) Tcl(tag int, gj float64) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n", firstLine(fs.Name))
+	if 0 < gj {
+		fmt.Fprintf(&buf, "section Fiber %d -GJ %.10g {\n", tag, gj)
+	} else {
+		fmt.Fprintf(&buf, "section Fiber %d {\n", tag)
+	}
+	for _, f := range fs.Fibres {
+		fmt.Fprintf(&buf, "    fiber %.10g %.10g %.10g %d\n", f.Y, f.Z, f.Area, f.Material)
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return buf.String()
+}
+
+// Python return fibre section for OpenSeesPy imported as `ops`. Torsional
+// stiffness gj is ignored for not positive value.
+func (fs FibreSection) Python(tag int, gj float64) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n", firstLine(fs.Name))
+	if 0 < gj {
+		fmt.Fprintf(&buf, "ops.section('Fiber', %d, '-GJ', %.10g)\n", tag, gj)
+	} else {
+		fmt.Fprintf(&buf, "ops.section('Fiber', %d)\n", tag)
+	}
+	for _, f := range fs.Fibres {
+		fmt.Fprintf(&buf, "ops.fiber(%.10g, %.10g, %.10g, %d)\n", f.Y, f.Z, f.Area, f.Material)
+	}
+	return buf.String()
+}
+
+// JSON return fibre section in JSON format
+func (fs FibreSection) JSON() ([]byte, error) {
+	return json.MarshalIndent(fs, "", "  ")
+}
+
+// firstLine return first line of text
+func firstLine(s string) string {
+	for i := range s {
+		if s[i] == '\n' {
+			return s[:i]
+		}
+	}
+	return s
+}
@@ -0,0 +1,754 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+)
+
+// Elastic buckling of thin-walled section by finite strip method with
+// simply supported ends and one half-wave along member:
+//
+//	u(x,y) = (u1*(1-x/b) + u2*x/b) * sin(k*y)  // transverse membrane
+//	v(x,y) = (v1*(1-x/b) + v2*x/b) * cos(k*y)  // longitudinal
+//	w(x,y) = (N1*w1 + N2*b*θ1 + N3*w2 + N4*b*θ2) * sin(k*y)  // bending
+//	k = pi / L,  L - half-wavelength
+//
+// where b is width of strip and N1..N4 are cubic Hermite functions.
+// Critical load factor is minimal positive eigenvalue of problem:
+//
+//	(K - factor * Kg) * d = 0
+//
+// where K is elastic stiffness and Kg is geometric stiffness for stress
+// of unit load. Curve of load factor versus half-wavelength is signature
+// curve, see CUFSM.
+//
+// See https://www.ce.jhu.edu/cufsm/
+type StripModel struct {
+	Nodes  []Point // nodes of center line
+	Strips []Strip // elements of center line
+}
+
+// Strip is flat element of center line model between 2 nodes
+type Strip struct {
+	Begin, End int     // index of nodes
+	Thk        float64 // thickness
+}
+
+// Striper is thin-walled section with center line model
+type Striper interface {
+	StripModel() StripModel
+}
+
+// Load is load of finite strip analysis
+type Load int
+
+const (
+	Compression Load = iota // unit compression force
+	BendingX                // unit moment around axe X, compression for positive Y
+	BendingY                // unit moment around axe Y, compression for positive X
+)
+
+func (l Load) String() string {
+	switch l {
+	case Compression:
+		return "Compression"
+	case BendingX:
+		return "Bending around axe X"
+	case BendingY:
+		return "Bending around axe Y"
+	}
+	return fmt.Sprintf("Load(%d)", int(l))
+}
+
+// BucklingPoint is point of signature curve
+type BucklingPoint struct {
+	Length float64 // half-wavelength
+	Factor float64 // load factor, critical force or moment
+}
+
+// Buckling is result of finite strip analysis. Local and distortional
+// modes are the lowest minima of signature curve classified by buckled
+// shape: fold lines of section move in-plane in distortional mode and
+// stay in place in local mode with bending of plates only. Minimum is
+// zero if not found. Load factor on long half-wavelength is global
+// buckling.
+type Buckling struct {
+	Load         Load
+	Curve        []BucklingPoint // signature curve
+	Local        BucklingPoint   // local buckling
+	Distortional BucklingPoint   // distortional buckling
+}
+
+func (b Buckling) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Load\t%s\n", b.Load)
+	fmt.Fprintf(w, "Local\t%s\t%s\n", efmt.Sprint(b.Local.Length), efmt.Sprint(b.Local.Factor))
+	fmt.Fprintf(w, "Distortional\t%s\t%s\n", efmt.Sprint(b.Distortional.Length), efmt.Sprint(b.Distortional.Factor))
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Length\tFactor\t\n")
+	for _, p := range b.Curve {
+		fmt.Fprintf(w, "%s\t%s\t\n", efmt.Sprint(p.Length), efmt.Sprint(p.Factor))
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// HalfWavelengths return `n` half-wavelengths from `min` to `max` with
+// logarithmic step
+func HalfWavelengths(min, max float64, n int) (ls []float64) {
+	for i := 0; i < n; i++ {
+		f := 0.0
+		if 1 < n {
+			f = float64(i) / float64(n-1)
+		}
+		ls = append(ls, min*math.Pow(max/min, f))
+	}
+	return
+}
+
+// stripDivision is amount of strips in element of center line model
+const stripDivision = 4
+
+// Validate center line model
+func (m StripModel) Validate() error {
+	if len(m.Strips) == 0 {
+		return fmt.Errorf("model without strips")
+	}
+	for i, s := range m.Strips {
+		switch {
+		case s.Begin < 0 || len(m.Nodes) <= s.Begin || s.End < 0 || len(m.Nodes) <= s.End:
+			return fmt.Errorf("strip %d: not valid index of node", i)
+		case s.Thk <= 0:
+			return fmt.Errorf("strip %d: thickness is not positive", i)
+		case distance(m.Nodes[s.Begin], m.Nodes[s.End]) <= 0:
+			return fmt.Errorf("strip %d: zero width", i)
+		}
+	}
+	return nil
+}
+
+// stress return compression stress in nodes for unit load
+func (m StripModel) stress(load Load) (s []float64) {
+	var a, sx, sy float64
+	for _, st := range m.Strips {
+		var (
+			p1 = m.Nodes[st.Begin]
+			p2 = m.Nodes[st.End]
+			da = distance(p1, p2) * st.Thk
+		)
+		a += da
+		sx += da * (p1.Y + p2.Y) / 2
+		sy += da * (p1.X + p2.X) / 2
+	}
+	xc, yc := sy/a, sx/a
+	var jxx, jyy float64
+	for _, st := range m.Strips {
+		var (
+			p1 = m.Nodes[st.Begin]
+			p2 = m.Nodes[st.End]
+			da = distance(p1, p2) * st.Thk
+		)
+		jxx += da * (math.Pow((p1.Y+p2.Y)/2-yc, 2) + math.Pow(p2.Y-p1.Y, 2)/12)
+		jyy += da * (math.Pow((p1.X+p2.X)/2-xc, 2) + math.Pow(p2.X-p1.X, 2)/12)
+	}
+	s = make([]float64, len(m.Nodes))
+	for i, p := range m.Nodes {
+		switch load {
+		case Compression:
+			s[i] = 1 / a
+		case BendingX:
+			s[i] = (p.Y - yc) / jxx
+		case BendingY:
+			s[i] = (p.X - xc) / jyy
+		}
+	}
+	return
+}
+
+// divide return model with elements divided on `n` strips
+func (m StripModel) divide(n int) (d StripModel) {
+	d.Nodes = append(d.Nodes, m.Nodes...)
+	for _, s := range m.Strips {
+		var (
+			p1   = m.Nodes[s.Begin]
+			p2   = m.Nodes[s.End]
+			last = s.Begin
+		)
+		for i := 1; i <= n; i++ {
+			next := s.End
+			if i < n {
+				f := float64(i) / float64(n)
+				d.Nodes = append(d.Nodes, Point{
+					X: p1.X + (p2.X-p1.X)*f,
+					Y: p1.Y + (p2.Y-p1.Y)*f,
+				})
+				next = len(d.Nodes) - 1
+			}
+			d.Strips = append(d.Strips, Strip{Begin: last, End: next, Thk: s.Thk})
+			last = next
+		}
+	}
+	return
+}
+
+// FiniteStrip return signature curve of section for load by finite
+// strip method. Material is elastic with modulus `e` and Poisson's
+// ratio `nu`. Half-wavelengths are chosen automatically for nil
+// `lengths`, see HalfWavelengths.
+func FiniteStrip(m StripModel, load Load, e, nu float64, lengths []float64) (b Buckling, err error) {
+	if err = m.Validate(); err != nil {
+		return
+	}
+	if e <= 0 || nu < 0 || 0.5 <= nu {
+		err = fmt.Errorf("material is not valid: E = %e, nu = %e", e, nu)
+		return
+	}
+	if lengths == nil {
+		var (
+			minWidth = math.Inf(1)
+			size     float64
+		)
+		for _, s := range m.Strips {
+			minWidth = math.Min(minWidth, distance(m.Nodes[s.Begin], m.Nodes[s.End]))
+		}
+		for _, p1 := range m.Nodes {
+			for _, p2 := range m.Nodes {
+				size = math.Max(size, distance(p1, p2))
+			}
+		}
+		lengths = HalfWavelengths(minWidth/2, 50*size, 60)
+	}
+	b.Load = load
+	var (
+		d = m.divide(stripDivision)
+		s = d.stress(load)
+	)
+	for _, l := range lengths {
+		if l <= 0 {
+			err = fmt.Errorf("half-wavelength is not positive: %e", l)
+			return
+		}
+		var f float64
+		f, err = d.factor(s, l, e, nu)
+		if err != nil {
+			err = fmt.Errorf("half-wavelength %e: %v", l, err)
+			return
+		}
+		b.Curve = append(b.Curve, BucklingPoint{Length: l, Factor: f})
+	}
+	// minima of signature curve, nodes of model keep indexes in divided model
+	folds := m.folds()
+	for i := 1; i+1 < len(b.Curve); i++ {
+		p := b.Curve[i]
+		if !(p.Factor < b.Curve[i-1].Factor && p.Factor <= b.Curve[i+1].Factor) {
+			continue
+		}
+		var distortional bool
+		distortional, err = d.distortional(folds, s, p, e, nu)
+		if err != nil {
+			err = fmt.Errorf("half-wavelength %e: %v", p.Length, err)
+			return
+		}
+		mode := &b.Local
+		if distortional {
+			mode = &b.Distortional
+		}
+		if mode.Length == 0 || p.Factor < mode.Factor {
+			*mode = p
+		}
+	}
+	return
+}
+
+// distortional return true for buckled shape of minimum `p` with
+// in-plane displacement of fold lines `folds`. Displacements of fold
+// lines are compared with maximal displacement of nodes.
+func (m StripModel) distortional(folds []int, s []float64, p BucklingPoint, e, nu float64) (_ bool, err error) {
+	ke, kg := m.matrices(s, p.Length, e, nu)
+	d, err := shape(ke, kg, p.Factor)
+	if err != nil {
+		return
+	}
+	var all, fold float64
+	for i := range m.Nodes {
+		all = math.Max(all, math.Hypot(d[4*i], d[4*i+1]))
+	}
+	for _, i := range folds {
+		fold = math.Max(fold, math.Hypot(d[4*i], d[4*i+1]))
+	}
+	return all/2 < fold, nil
+}
+
+// gauss4 is points and weights of Gauss quadrature on [0,1]
+var gauss4 = [4][2]float64{
+	{0.5 - 0.8611363115940526/2, 0.3478548451374538 / 2},
+	{0.5 - 0.3399810435848563/2, 0.6521451548625461 / 2},
+	{0.5 + 0.3399810435848563/2, 0.6521451548625461 / 2},
+	{0.5 + 0.8611363115940526/2, 0.3478548451374538 / 2},
+}
+
+// stripMatrix return elastic and geometric stiffness matrix of strip in
+// local coordinates. Degrees of freedom are [u1 u2 v1 v2 w1 θ1 w2 θ2].
+// Stresses in nodes of strip are `s1` and `s2`. Common factor L/2 of
+// integrals along member is omitted.
+func stripMatrix(b, t, s1, s2, k, e, nu float64) (ke, kg [8][8]float64) {
+	var (
+		dm = e * t / (1 - nu*nu)
+		db = dm * t * t / 12
+	)
+	for _, g := range gauss4 {
+		var (
+			x  = g[0]
+			wg = g[1] * b
+			// Hermite functions and derivatives by x
+			n   = [4]float64{1 - 3*x*x + 2*x*x*x, b * (x - 2*x*x + x*x*x), 3*x*x - 2*x*x*x, b * (-x*x + x*x*x)}
+			n1  = [4]float64{(-6*x + 6*x*x) / b, 1 - 4*x + 3*x*x, (6*x - 6*x*x) / b, -2*x + 3*x*x}
+			n2  = [4]float64{(-6 + 12*x) / b / b, (-4 + 6*x) / b, (6 - 12*x) / b / b, (-2 + 6*x) / b}
+			ex  [8]float64 // strain by x
+			ey  [8]float64 // strain by y
+			gxy [8]float64 // shear strain
+			kx  [8]float64 // curvature by x
+			ky  [8]float64 // curvature by y
+			kxy [8]float64 // twist
+			fu  [8]float64 // function u
+			fv  [8]float64 // function v
+			fw  [8]float64 // function w
+		)
+		ex[0], ex[1] = -1/b, 1/b
+		ey[2], ey[3] = -k*(1-x), -k*x
+		gxy[0], gxy[1], gxy[2], gxy[3] = k*(1-x), k*x, -1/b, 1/b
+		fu[0], fu[1] = 1-x, x
+		fv[2], fv[3] = 1-x, x
+		for i := range n {
+			kx[4+i] = -n2[i]
+			ky[4+i] = k * k * n[i]
+			kxy[4+i] = 2 * k * n1[i]
+			fw[4+i] = n[i]
+		}
+		st := (s1*(1-x) + s2*x) * t
+		for i := 0; i < 8; i++ {
+			for j := 0; j < 8; j++ {
+				ke[i][j] += wg * (dm*(ex[i]*ex[j]+ey[i]*ey[j]+nu*(ex[i]*ey[j]+ey[i]*ex[j])+
+					(1-nu)/2*gxy[i]*gxy[j]) +
+					db*(kx[i]*kx[j]+ky[i]*ky[j]+nu*(kx[i]*ky[j]+ky[i]*kx[j])+
+						(1-nu)/2*kxy[i]*kxy[j]))
+				kg[i][j] += wg * st * k * k * (fu[i]*fu[j] + fv[i]*fv[j] + fw[i]*fw[j])
+			}
+		}
+	}
+	return
+}
+
+// factor return minimal positive load factor for half-wavelength `l`.
+// Stresses in nodes are `s`.
+func (m StripModel) factor(s []float64, l, e, nu float64) (f float64, err error) {
+	mu, err := maxEigen(m.matrices(s, l, e, nu))
+	if err != nil {
+		return
+	}
+	if mu <= 0 {
+		return math.Inf(1), nil
+	}
+	return 1 / mu, nil
+}
+
+// matrices return global elastic and geometric stiffness matrices for
+// half-wavelength `l`. Degrees of freedom of node are [X Y v θ].
+func (m StripModel) matrices(s []float64, l, e, nu float64) (ke, kg [][]float64) {
+	var (
+		size = 4 * len(m.Nodes)
+		k    = math.Pi / l
+	)
+	ke = make([][]float64, size)
+	kg = make([][]float64, size)
+	for i := range ke {
+		ke[i] = make([]float64, size)
+		kg[i] = make([]float64, size)
+	}
+	for _, st := range m.Strips {
+		var (
+			p1 = m.Nodes[st.Begin]
+			p2 = m.Nodes[st.End]
+			b  = distance(p1, p2)
+			c  = (p2.X - p1.X) / b
+			sn = (p2.Y - p1.Y) / b
+		)
+		lke, lkg := stripMatrix(b, st.Thk, s[st.Begin], s[st.End], k, e, nu)
+		// local degrees of freedom by global: u = c*X + s*Y, w = -s*X + c*Y
+		var (
+			tr  [8][8]float64
+			dof [8]int
+		)
+		for i, node := range []int{st.Begin, st.End} {
+			var (
+				u = i     // u1, u2
+				v = 2 + i // v1, v2
+				w = 4 + 2*i
+				r = 5 + 2*i
+			)
+			tr[u][4*i+0], tr[u][4*i+1] = c, sn
+			tr[v][4*i+2] = 1
+			tr[w][4*i+0], tr[w][4*i+1] = -sn, c
+			tr[r][4*i+3] = 1
+			for j := 0; j < 4; j++ {
+				dof[4*i+j] = 4*node + j
+			}
+		}
+		gke, gkg := transformMatrix(lke, tr), transformMatrix(lkg, tr)
+		for i := range dof {
+			for j := range dof {
+				ke[dof[i]][dof[j]] += gke[i][j]
+				kg[dof[i]][dof[j]] += gkg[i][j]
+			}
+		}
+	}
+	return
+}
+
+// shape return buckled shape for load factor `f` by inverse iteration
+// (ke - f*kg) * d(i+1) = kg * d(i). Shape is normalized by maximal
+// component.
+func shape(ke, kg [][]float64, f float64) (d []float64, err error) {
+	var (
+		n     = len(ke)
+		shift = f * (1 - 1e-9)
+		a     = make([][]float64, n)
+	)
+	for i := range a {
+		a[i] = make([]float64, n)
+		for j := range a[i] {
+			a[i][j] = ke[i][j] - shift*kg[i][j]
+		}
+	}
+	d = make([]float64, n)
+	for i := range d {
+		d[i] = 1
+	}
+	for iter := 0; iter < 4; iter++ {
+		b := make([]float64, n)
+		for i := range b {
+			for j := range d {
+				b[i] += kg[i][j] * d[j]
+			}
+		}
+		if d, err = gauss(a, b); err != nil {
+			return
+		}
+		var max float64
+		for _, v := range d {
+			max = math.Max(max, math.Abs(v))
+		}
+		if max == 0 {
+			err = fmt.Errorf("zero buckled shape")
+			return
+		}
+		for i := range d {
+			d[i] /= max
+		}
+	}
+	return
+}
+
+// folds return indexes of nodes, where strips with different directions
+// are connected
+func (m StripModel) folds() (fs []int) {
+	dirs := make([][]Point, len(m.Nodes))
+	for _, st := range m.Strips {
+		var (
+			p1 = m.Nodes[st.Begin]
+			p2 = m.Nodes[st.End]
+			b  = distance(p1, p2)
+			u  = Point{X: (p2.X - p1.X) / b, Y: (p2.Y - p1.Y) / b}
+		)
+		dirs[st.Begin] = append(dirs[st.Begin], u)
+		dirs[st.End] = append(dirs[st.End], u)
+	}
+	for i, ds := range dirs {
+	next:
+		for j := range ds {
+			for k := j + 1; k < len(ds); k++ {
+				if 1e-6 < math.Abs(ds[j].X*ds[k].Y-ds[j].Y*ds[k].X) {
+					fs = append(fs, i)
+					break next
+				}
+			}
+		}
+	}
+	return
+}
+
+// transformMatrix return tr^T * a * tr
+func transformMatrix(a, tr [8][8]float64) (res [8][8]float64) {
+	var at [8][8]float64
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			for k := 0; k < 8; k++ {
+				at[i][j] += a[i][k] * tr[k][j]
+			}
+		}
+	}
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			for k := 0; k < 8; k++ {
+				res[i][j] += tr[k][i] * at[k][j]
+			}
+		}
+	}
+	return
+}
+
+// maxEigen return maximal eigenvalue `mu` of problem kg*d = mu*ke*d for
+// symmetric positive definite matrix `ke`. Problem is reduced to
+// standard problem by Cholesky decomposition ke = L*L^T, then matrix is
+// reduced to tridiagonal by Householder reflections and eigenvalue is
+// found by bisection with Sturm sequence.
+func maxEigen(ke, kg [][]float64) (mu float64, err error) {
+	n := len(ke)
+	// Cholesky decomposition
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+		for j := 0; j <= i; j++ {
+			v := ke[i][j]
+			for k := 0; k < j; k++ {
+				v -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if v <= 0 {
+					err = fmt.Errorf("stiffness matrix is not positive definite")
+					return
+				}
+				l[i][i] = math.Sqrt(v)
+				continue
+			}
+			l[i][j] = v / l[j][j]
+		}
+	}
+	// c = L^-1 * kg * L^-T
+	c := make([][]float64, n)
+	for i := range c {
+		c[i] = append([]float64{}, kg[i]...)
+	}
+	for col := 0; col < n; col++ { // L^-1 * kg
+		for i := 0; i < n; i++ {
+			v := c[i][col]
+			for k := 0; k < i; k++ {
+				v -= l[i][k] * c[k][col]
+			}
+			c[i][col] = v / l[i][i]
+		}
+	}
+	for row := 0; row < n; row++ { // (L^-1 * (L^-1 * kg)^T)^T
+		for i := 0; i < n; i++ {
+			v := c[row][i]
+			for k := 0; k < i; k++ {
+				v -= l[i][k] * c[row][k]
+			}
+			c[row][i] = v / l[i][i]
+		}
+	}
+	// Householder reduction to tridiagonal matrix
+	var (
+		d = make([]float64, n) // diagonal
+		e = make([]float64, n) // subdiagonal, e[i] = c[i][i-1]
+		v = make([]float64, n)
+		p = make([]float64, n)
+	)
+	for k := 0; k < n-2; k++ {
+		var norm float64
+		for i := k + 1; i < n; i++ {
+			norm += c[i][k] * c[i][k]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			continue
+		}
+		alpha := -math.Copysign(norm, c[k+1][k])
+		var vn float64
+		for i := k + 1; i < n; i++ {
+			v[i] = c[i][k]
+			if i == k+1 {
+				v[i] -= alpha
+			}
+			vn += v[i] * v[i]
+		}
+		vn = math.Sqrt(vn)
+		if vn == 0 {
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			v[i] /= vn
+		}
+		// p = c*v, w = p - (v^T*p)*v, c = c - 2*v*w^T - 2*w*v^T
+		var vp float64
+		for i := k + 1; i < n; i++ {
+			p[i] = 0
+			for j := k + 1; j < n; j++ {
+				p[i] += c[i][j] * v[j]
+			}
+			vp += v[i] * p[i]
+		}
+		for i := k + 1; i < n; i++ {
+			p[i] -= vp * v[i]
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				c[i][j] -= 2 * (v[i]*p[j] + p[i]*v[j])
+			}
+		}
+		c[k+1][k], c[k][k+1] = alpha, alpha
+		for i := k + 2; i < n; i++ {
+			c[i][k], c[k][i] = 0, 0
+		}
+	}
+	for i := 0; i < n; i++ {
+		d[i] = c[i][i]
+		if 0 < i {
+			e[i] = c[i][i-1]
+		}
+	}
+	// Gershgorin bounds
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i := 0; i < n; i++ {
+		r := math.Abs(e[i])
+		if i+1 < n {
+			r += math.Abs(e[i+1])
+		}
+		lo = math.Min(lo, d[i]-r)
+		hi = math.Max(hi, d[i]+r)
+	}
+	// amount of eigenvalues less `x`
+	count := func(x float64) (amount int) {
+		q := 1.0
+		for i := 0; i < n; i++ {
+			if i == 0 {
+				q = d[i] - x
+			} else {
+				q = d[i] - x - e[i]*e[i]/q
+			}
+			if q == 0 {
+				q = 1e-300
+			}
+			if q < 0 {
+				amount++
+			}
+		}
+		return
+	}
+	scale := math.Max(math.Abs(lo), math.Abs(hi))
+	for iter := 0; iter < 200 && 1e-12*scale < hi-lo; iter++ {
+		mid := (lo + hi) / 2
+		if count(mid) == n {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return (lo + hi) / 2, nil
+}
+
+// polyline return center line model of polyline with constant thickness
+func polyline(ps []Point, t float64) (m StripModel) {
+	m.Nodes = ps
+	for i := 1; i < len(ps); i++ {
+		m.Strips = append(m.Strips, Strip{Begin: i - 1, End: i, Thk: t})
+	}
+	return
+}
+
+// StripModel of LippedChannel without bends
+func (l LippedChannel) StripModel() StripModel {
+	return polyline(l.centerLine(), l.Thk)
+}
+
+// StripModel of LippedZ without bends
+func (z LippedZ) StripModel() StripModel {
+	return polyline(z.centerLine(), z.Thk)
+}
+
+// StripModel of Sigma without bends
+func (s Sigma) StripModel() StripModel {
+	return polyline(s.centerLine(), s.Thk)
+}
+
+// StripModel of Hat without bends
+func (h Hat) StripModel() StripModel {
+	return polyline(h.centerLine(), h.Thk)
+}
+
+// StripModel of Angle without fillets
+func (a Angle) StripModel() StripModel {
+	t := a.Thk / 2
+	return polyline([]Point{{a.Width, t}, {t, t}, {t, a.Width}}, a.Thk)
+}
+
+// StripModel of UnequalAngle without fillets
+func (a UnequalAngle) StripModel() StripModel {
+	t := a.Thk / 2
+	return polyline([]Point{{a.Width2, t}, {t, t}, {t, a.Width1}}, a.Thk)
+}
+
+// StripModel of Isection without fillets
+func (is Isection) StripModel() StripModel {
+	var (
+		y1 = is.Tf / 2
+		y2 = is.H - is.Tf/2
+		xc = is.B / 2
+	)
+	return StripModel{
+		Nodes: []Point{{0, y1}, {xc, y1}, {is.B, y1}, {0, y2}, {xc, y2}, {is.B, y2}},
+		Strips: []Strip{
+			{0, 1, is.Tf}, {1, 2, is.Tf},
+			{1, 4, is.Tw},
+			{3, 4, is.Tf}, {4, 5, is.Tf},
+		},
+	}
+}
+
+// StripModel of UPN with parallel flanges and without fillets
+func (u UPN) StripModel() StripModel {
+	var (
+		x = u.Tw / 2
+		t = u.Tf / 2
+	)
+	return StripModel{
+		Nodes: []Point{{u.B, t}, {x, t}, {x, u.H - t}, {u.B, u.H - t}},
+		Strips: []Strip{
+			{0, 1, u.Tf},
+			{1, 2, u.Tw},
+			{2, 3, u.Tf},
+		},
+	}
+}
+
+// StripModel of Tsection
+func (t Tsection) StripModel() StripModel {
+	y := -t.Thk2 / 4
+	return StripModel{
+		Nodes: []Point{{-t.L / 2, y}, {0, y}, {t.L / 2, y}, {0, t.H}},
+		Strips: []Strip{
+			{0, 1, t.Thk2 / 2}, {1, 2, t.Thk2 / 2},
+			{1, 3, t.Thk},
+		},
+	}
+}
+
+// StripModel of RHS without corner radii
+func (r RHS) StripModel() StripModel {
+	var (
+		x = (r.B - r.Thk) / 2
+		y = (r.H - r.Thk) / 2
+	)
+	return StripModel{
+		Nodes: []Point{{-x, -y}, {x, -y}, {x, y}, {-x, y}},
+		Strips: []Strip{
+			{0, 1, r.Thk}, {1, 2, r.Thk}, {2, 3, r.Thk}, {3, 0, r.Thk},
+		},
+	}
+}
@@ -0,0 +1,341 @@
+package section
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Law is uniaxial stress-strain law. Strain and stress are positive in
+// compression. Breaks is strains with discontinuity of derivative of
+// stress, stress is smooth function between breaks. Yield and Ultimate
+// return strains of yield and failure in tension (negative value) and
+// in compression, infinite strain for not limited law.
+type Law interface {
+	Stress(strain float64) float64
+	Breaks() []float64
+	Yield() (tension, compression float64)
+	Ultimate() (tension, compression float64)
+}
+
+// Concrete is parabola-rectangle law of concrete for design by
+// EN 1992-1-1 section 3.1.7. Tension strength is ignored.
+//
+//	stress = Fcd*(1-(1-strain/Epsc2)^N) for 0 <= strain < Epsc2
+//	stress = Fcd                        for Epsc2 <= strain
+type Concrete struct {
+	Name   string
+	Fck    float64 // characteristic cylinder strength, Pa
+	Fcd    float64 // design compressive strength, Pa
+	Fctm   float64 // mean tensile strength, Pa
+	Ecm    float64 // secant modulus of elasticity, Pa
+	Epsc2  float64 // strain at reaching maximal strength
+	Epscu2 float64 // ultimate strain
+	N      float64 // exponent of parabola
+}
+
+// NewConcrete return concrete by EN 1992-1-1 Table 3.1 for
+// characteristic strength fck in Pa with partial factor 1.5
+func NewConcrete(fck float64) Concrete {
+	var (
+		f   = fck / 1e6 // MPa
+		fcm = f + 8
+		c   = Concrete{
+			Name:   fmt.Sprintf("C%.0f", f),
+			Fck:    fck,
+			Fcd:    fck / 1.5,
+			Ecm:    22e9 * math.Pow(fcm/10, 0.3),
+			Epsc2:  0.002,
+			Epscu2: 0.0035,
+			N:      2,
+		}
+	)
+	c.Fctm = 0.30e6 * math.Pow(f, 2.0/3.0)
+	if 50 < f {
+		c.Fctm = 2.12e6 * math.Log(1+fcm/10)
+		c.Epsc2 = (2.0 + 0.085*math.Pow(f-50, 0.53)) / 1e3
+		c.Epscu2 = (2.6 + 35*math.Pow((90-f)/100, 4)) / 1e3
+		c.N = 1.4 + 23.4*math.Pow((90-f)/100, 4)
+	}
+	return c
+}
+
+func (c Concrete) Validate() error {
+	switch {
+	case c.Fcd <= 0 || c.Ecm <= 0:
+		return fmt.Errorf("concrete %s: strength or modulus is not positive", c.Name)
+	case c.Epsc2 <= 0 || c.Epscu2 < c.Epsc2 || c.N <= 0:
+		return fmt.Errorf("concrete %s: not valid strains", c.Name)
+	}
+	return nil
+}
+
+func (c Concrete) Stress(strain float64) float64 {
+	switch {
+	case strain <= 0:
+		return 0
+	case strain < c.Epsc2:
+		return c.Fcd * (1 - math.Pow(1-strain/c.Epsc2, c.N))
+	}
+	return c.Fcd
+}
+
+func (c Concrete) Breaks() []float64 {
+	return []float64{0, c.Epsc2}
+}
+
+func (c Concrete) Yield() (tension, compression float64) {
+	return math.Inf(-1), c.Epsc2
+}
+
+func (c Concrete) Ultimate() (tension, compression float64) {
+	return math.Inf(-1), c.Epscu2
+}
+
+// Rebar is bilinear law of reinforcement with horizontal top branch for
+// design by EN 1992-1-1 section 3.2.7
+type Rebar struct {
+	Name  string
+	Fyk   float64 // characteristic yield strength, Pa
+	Fyd   float64 // design yield strength, Pa
+	Es    float64 // modulus of elasticity, Pa
+	Epsud float64 // ultimate strain
+}
+
+// NewRebar return reinforcement of ductility class B for
+// characteristic yield strength fyk in Pa with partial factor 1.15
+func NewRebar(fyk float64) Rebar {
+	return Rebar{
+		Name:  fmt.Sprintf("B%.0fB", fyk/1e6),
+		Fyk:   fyk,
+		Fyd:   fyk / 1.15,
+		Es:    200e9,
+		Epsud: 0.9 * 0.05,
+	}
+}
+
+func (r Rebar) Validate() error {
+	if r.Fyd <= 0 || r.Es <= 0 || r.Epsud <= r.Fyd/r.Es {
+		return fmt.Errorf("reinforcement %s: not valid property", r.Name)
+	}
+	return nil
+}
+
+func (r Rebar) Stress(strain float64) float64 {
+	return math.Max(-r.Fyd, math.Min(r.Fyd, r.Es*strain))
+}
+
+func (r Rebar) Breaks() []float64 {
+	return []float64{-r.Fyd / r.Es, r.Fyd / r.Es}
+}
+
+func (r Rebar) Yield() (tension, compression float64) {
+	return -r.Fyd / r.Es, r.Fyd / r.Es
+}
+
+func (r Rebar) Ultimate() (tension, compression float64) {
+	return -r.Epsud, r.Epsud
+}
+
+// ElasticPlastic is elastic-perfectly plastic law with equal properties
+// in tension and compression
+type ElasticPlastic struct {
+	E    float64 // modulus of elasticity, Pa
+	Fy   float64 // yield strength, Pa
+	Epsu float64 // ultimate strain
+}
+
+func (l ElasticPlastic) Stress(strain float64) float64 {
+	return math.Max(-l.Fy, math.Min(l.Fy, l.E*strain))
+}
+
+func (l ElasticPlastic) Breaks() []float64 {
+	return []float64{-l.Fy / l.E, l.Fy / l.E}
+}
+
+func (l ElasticPlastic) Yield() (tension, compression float64) {
+	return -l.Fy / l.E, l.Fy / l.E
+}
+
+func (l ElasticPlastic) Ultimate() (tension, compression float64) {
+	return -l.Epsu, l.Epsu
+}
+
+// Bilinear is elastic-plastic law with linear hardening and equal
+// properties in tension and compression
+type Bilinear struct {
+	E    float64 // modulus of elasticity, Pa
+	Fy   float64 // yield strength, Pa
+	Et   float64 // modulus of hardening, Pa
+	Epsu float64 // ultimate strain
+}
+
+func (l Bilinear) Stress(strain float64) float64 {
+	ey := l.Fy / l.E
+	switch {
+	case ey < strain:
+		return l.Fy + l.Et*(strain-ey)
+	case strain < -ey:
+		return -l.Fy + l.Et*(strain+ey)
+	}
+	return l.E * strain
+}
+
+func (l Bilinear) Breaks() []float64 {
+	return []float64{-l.Fy / l.E, l.Fy / l.E}
+}
+
+func (l Bilinear) Yield() (tension, compression float64) {
+	return -l.Fy / l.E, l.Fy / l.E
+}
+
+func (l Bilinear) Ultimate() (tension, compression float64) {
+	return -l.Epsu, l.Epsu
+}
+
+// RambergOsgood is law with equal properties in tension and compression
+//
+//	strain = stress/E + 0.002*(stress/Fy)^N
+//
+// Yield strength Fy is 0.2% proof stress.
+type RambergOsgood struct {
+	E    float64 // modulus of elasticity, Pa
+	Fy   float64 // 0.2% proof stress, Pa
+	N    float64 // exponent
+	Epsu float64 // ultimate strain
+}
+
+func (l RambergOsgood) Stress(strain float64) float64 {
+	e := math.Abs(strain)
+	// Newton iterations from upper bound of convex function
+	s := math.Min(l.E*e, l.Fy*math.Pow(e/0.002, 1/l.N))
+	for iter := 0; iter < IterMax; iter++ {
+		var (
+			r  = s / l.Fy
+			f  = s/l.E + 0.002*math.Pow(r, l.N) - e
+			df = 1/l.E + 0.002*l.N*math.Pow(r, l.N-1)/l.Fy
+			ds = f / df
+		)
+		s -= ds
+		if math.Abs(ds) <= Eps*Eps*l.Fy {
+			break
+		}
+	}
+	return math.Copysign(s, strain)
+}
+
+func (l RambergOsgood) Breaks() []float64 {
+	return nil
+}
+
+func (l RambergOsgood) Yield() (tension, compression float64) {
+	e := l.Fy/l.E + 0.002
+	return -e, e
+}
+
+func (l RambergOsgood) Ultimate() (tension, compression float64) {
+	return -l.Epsu, l.Epsu
+}
+
+// StressResultant is resultant of stresses in section. Axial force is
+// positive in compression, positive moments compress fibres with
+// positive Y and X. Sign is opposite to Forces, so resultant in
+// equilibrium with internal forces f is:
+//
+//	StressResultant{N: -f.N, Mx: -f.Mx, My: -f.My}
+type StressResultant struct {
+	N, Mx, My float64
+}
+
+// plane is linear strain field:
+//
+//	strain = E0 + Kx*x + Ky*y
+type plane struct {
+	E0, Kx, Ky float64
+}
+
+func (p plane) strain(x, y float64) float64 {
+	return p.E0 + p.Kx*x + p.Ky*y
+}
+
+// dunavant is 6 point quadrature rule of degree 4 on triangle with
+// barycentric coordinates and weights
+var dunavant = [6][4]float64{
+	{0.445948490915965, 0.445948490915965, 0.108103018168070, 0.223381589678011},
+	{0.445948490915965, 0.108103018168070, 0.445948490915965, 0.223381589678011},
+	{0.108103018168070, 0.445948490915965, 0.445948490915965, 0.223381589678011},
+	{0.091576213509771, 0.091576213509771, 0.816847572980459, 0.109951743655322},
+	{0.091576213509771, 0.816847572980459, 0.091576213509771, 0.109951743655322},
+	{0.816847572980459, 0.091576213509771, 0.091576213509771, 0.109951743655322},
+}
+
+// stresses return resultant of stresses of triangles for strain plane
+// and law. Triangles are split by lines of breaks of law, so integration is
+// exact for parabola and linear laws.
+func stresses(ts []triangle, p plane, law Law) (f StressResultant) {
+	breaks := append([]float64{}, law.Breaks()...)
+	sort.Float64s(breaks)
+	for _, t := range ts {
+		pieces := [][]Point{{
+			{t.x[0], t.y[0]}, {t.x[1], t.y[1]}, {t.x[2], t.y[2]},
+		}}
+		for _, b := range breaks {
+			var next [][]Point
+			for _, piece := range pieces {
+				below, above := split(piece, p, b)
+				next = append(next, below, above)
+			}
+			pieces = next
+		}
+		for _, piece := range pieces {
+			for i := 2; i < len(piece); i++ {
+				a, b, c := piece[0], piece[i-1], piece[i]
+				area := ((b.X-a.X)*(c.Y-a.Y) - (c.X-a.X)*(b.Y-a.Y)) / 2
+				area = math.Abs(area)
+				if area == 0 {
+					continue
+				}
+				for _, q := range dunavant {
+					x := q[0]*a.X + q[1]*b.X + q[2]*c.X
+					y := q[0]*a.Y + q[1]*b.Y + q[2]*c.Y
+					s := q[3] * area * law.Stress(p.strain(x, y))
+					f.N += s
+					f.Mx += s * y
+					f.My += s * x
+				}
+			}
+		}
+	}
+	return
+}
+
+// split return parts of convex polygon with strain less and more than
+// value b
+func split(poly []Point, p plane, b float64) (below, above []Point) {
+	for i := range poly {
+		var (
+			c  = poly[i]
+			n  = poly[(i+1)%len(poly)]
+			ec = p.strain(c.X, c.Y) - b
+			en = p.strain(n.X, n.Y) - b
+		)
+		if ec < 0 {
+			below = append(below, c)
+		} else {
+			above = append(above, c)
+		}
+		if (ec < 0 && 0 < en) || (en < 0 && 0 < ec) {
+			r := ec / (ec - en)
+			m := Point{X: c.X + r*(n.X-c.X), Y: c.Y + r*(n.Y-c.Y)}
+			below = append(below, m)
+			above = append(above, m)
+		}
+	}
+	if len(below) < 3 {
+		below = nil
+	}
+	if len(above) < 3 {
+		above = nil
+	}
+	return
+}
@@ -0,0 +1,317 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+	"github.com/Konstantin8105/msh"
+)
+
+// Material is linear elastic material with equal strength in tension
+// and compression
+type Material struct {
+	Name    string
+	E       float64 // modulus of elasticity, Pa
+	G       float64 // shear modulus, Pa
+	Fy      float64 // yield strength, Pa
+	Density float64 // kg/m3
+}
+
+func (m Material) Validate() error {
+	switch {
+	case m.E <= 0 || m.G <= 0:
+		return fmt.Errorf("material %s: moduli are not positive", m.Name)
+	case m.Fy < 0 || m.Density < 0:
+		return fmt.Errorf("material %s: negative strength or density", m.Name)
+	}
+	return nil
+}
+
+// Region is section of one material. Section must be Bounder.
+type Region struct {
+	Geor
+	Material Material
+}
+
+// MultiMaterial is section from regions of different materials.
+// Regions must not overlap, regions with common edges are connected.
+//
+// Example of timber beam with steel flitch plate:
+//
+//	MultiMaterial{Regions: []Region{
+//		{Geor: Translate{Geor: Rectangle{H: 0.2, Thk: 0.05}, X: -0.03}, Material: timber},
+//		{Geor: Rectangle{H: 0.2, Thk: 0.01}, Material: steel},
+//		{Geor: Translate{Geor: Rectangle{H: 0.2, Thk: 0.05}, X: +0.03}, Material: timber},
+//	}}
+type MultiMaterial struct {
+	Name    string
+	Regions []Region
+}
+
+func (m MultiMaterial) GetName() string {
+	if m.Name != "" {
+		return m.Name
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "%s\n", "Multi-material section")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "№\tName\tMaterial\tE\tG\tFy\tDensity\n")
+	for i, r := range m.Regions {
+		name := "Undefined"
+		if r.Geor != nil {
+			name = r.GetName()
+		}
+		fmt.Fprintf(w,
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			i, name, r.Material.Name,
+			efmt.Sprint(r.Material.E), efmt.Sprint(r.Material.G),
+			efmt.Sprint(r.Material.Fy), efmt.Sprint(r.Material.Density),
+		)
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+func (m MultiMaterial) Geo(prec float64) string {
+	return BoundaryGeo(m.Boundaries(), prec)
+}
+
+func (m MultiMaterial) Validate() error {
+	if len(m.Regions) == 0 {
+		return fmt.Errorf("multi-material section without regions")
+	}
+	for i, r := range m.Regions {
+		if err := validateBounder(r.Geor); err != nil {
+			return fmt.Errorf("region %d: %v", i, err)
+		}
+		if err := r.Material.Validate(); err != nil {
+			return fmt.Errorf("region %d: %v", i, err)
+		}
+	}
+	rings := make([][][]Point, len(m.Regions))
+	for i, r := range m.Regions {
+		rings[i] = boundaryPolygons(transformed(r.Geor, false, 0, 0, 0))
+		for j := 0; j < i; j++ {
+			if overlap(rings[j], rings[i]) {
+				return fmt.Errorf("regions %d and %d are overlapped", j, i)
+			}
+		}
+	}
+	return nil
+}
+
+// Boundaries of MultiMaterial
+func (m MultiMaterial) Boundaries() (bs []Boundary) {
+	for _, r := range m.Regions {
+		bs = append(bs, transformed(r.Geor, false, 0, 0, 0)...)
+	}
+	return
+}
+
+// MaterialProperty is property of multi-material section. Stiffnesses
+// are calculated at the elastic center (X,Y) weighted by moduli of
+// elasticity. Transformed area and moments of inertia are stiffnesses
+// divided by reference modulus Eref.
+//
+// Plastic axial force is sum of Fy*A of regions. Plastic moments are
+// calculated around plastic neutral axes parallel to axes X and Y with
+// equal forces in compression and tension.
+type MaterialProperty struct {
+	Eref float64 // reference modulus of elasticity
+	X, Y float64 // elastic center
+
+	EA               float64 // axial stiffness
+	EIxx, EIyy, EIxy float64 // bending stiffnesses
+	GJ               float64 // torsional stiffness
+
+	A, Jxx, Jyy, Jxy float64 // transformed section
+
+	Mass float64 // mass per unit length, kg/m
+
+	NPlastic             float64 // plastic axial force
+	MxPlastic, MyPlastic float64 // plastic moments
+	YPlastic, XPlastic   float64 // location of plastic neutral axes
+}
+
+func (p MaterialProperty) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Eref\t%s\tReference modulus of elasticity\n", efmt.Sprint(p.Eref))
+	fmt.Fprintf(w, "X\t%s\tlocation elastic center by axe X\n", efmt.Sprint(p.X))
+	fmt.Fprintf(w, "Y\t%s\tlocation elastic center by axe Y\n", efmt.Sprint(p.Y))
+	fmt.Fprintf(w, "EA\t%s\tAxial stiffness\n", efmt.Sprint(p.EA))
+	fmt.Fprintf(w, "EIxx\t%s\tBending stiffness around axe X\n", efmt.Sprint(p.EIxx))
+	fmt.Fprintf(w, "EIyy\t%s\tBending stiffness around axe Y\n", efmt.Sprint(p.EIyy))
+	fmt.Fprintf(w, "EIxy\t%s\tProduct bending stiffness\n", efmt.Sprint(p.EIxy))
+	fmt.Fprintf(w, "GJ\t%s\tTorsional stiffness\n", efmt.Sprint(p.GJ))
+	fmt.Fprintf(w, "A\t%s\tTransformed area\n", efmt.Sprint(p.A))
+	fmt.Fprintf(w, "Jxx\t%s\tTransformed moment inertia around axe X\n", efmt.Sprint(p.Jxx))
+	fmt.Fprintf(w, "Jyy\t%s\tTransformed moment inertia around axe Y\n", efmt.Sprint(p.Jyy))
+	fmt.Fprintf(w, "Jxy\t%s\tTransformed product moment inertia\n", efmt.Sprint(p.Jxy))
+	fmt.Fprintf(w, "Mass\t%s\tMass per unit length\n", efmt.Sprint(p.Mass))
+	fmt.Fprintf(w, "NPlastic\t%s\tPlastic axial force\n", efmt.Sprint(p.NPlastic))
+	fmt.Fprintf(w, "MxPlastic\t%s\tPlastic moment around axe X\n", efmt.Sprint(p.MxPlastic))
+	fmt.Fprintf(w, "YPlastic\t%s\tLocation of plastic neutral axe by axe Y\n", efmt.Sprint(p.YPlastic))
+	fmt.Fprintf(w, "MyPlastic\t%s\tPlastic moment around axe Y\n", efmt.Sprint(p.MyPlastic))
+	fmt.Fprintf(w, "XPlastic\t%s\tLocation of plastic neutral axe by axe X\n", efmt.Sprint(p.XPlastic))
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// CalculateMaterial return property of multi-material section for
+// reference modulus of elasticity eref. Torsional stiffness is
+// calculated on triangle mesh, other properties by contours of regions.
+func CalculateMaterial(m MultiMaterial, eref float64) (p MaterialProperty, err error) {
+	if err = m.Validate(); err != nil {
+		return
+	}
+	if eref <= 0 {
+		err = fmt.Errorf("reference modulus is not positive: %e", eref)
+		return
+	}
+	p.Eref = eref
+	rings := make([][][]Point, len(m.Regions))
+	for i, r := range m.Regions {
+		if rings[i], err = contours(r.Geor); err != nil {
+			err = fmt.Errorf("region %d: %v", i, err)
+			return
+		}
+	}
+	// elastic center
+	var ex, ey float64
+	for i, r := range m.Regions {
+		pi := integrate(rings[i])
+		p.EA += r.Material.E * pi.A
+		ex += r.Material.E * pi.Sy
+		ey += r.Material.E * pi.Sx
+		p.Mass += r.Material.Density * pi.A
+		p.NPlastic += r.Material.Fy * pi.A
+	}
+	p.X, p.Y = ex/p.EA, ey/p.EA
+	for i, r := range m.Regions {
+		movePolygons(rings[i], -p.X, -p.Y)
+		pi := integrate(rings[i])
+		p.EIxx += r.Material.E * pi.Jxx
+		p.EIyy += r.Material.E * pi.Jyy
+		p.EIxy += r.Material.E * pi.Jxy
+	}
+	p.A, p.Jxx, p.Jyy, p.Jxy = p.EA/eref, p.EIxx/eref, p.EIyy/eref, p.EIxy/eref
+
+	// plastic moments
+	fy := make([]float64, len(m.Regions))
+	for i, r := range m.Regions {
+		fy[i] = r.Material.Fy
+	}
+	var yp float64
+	yp, p.MxPlastic = plasticMoment(rings, fy)
+	p.YPlastic = yp + p.Y
+	for i := range rings {
+		rotatePolygons(rings[i], math.Pi/2)
+	}
+	var xp float64
+	xp, p.MyPlastic = plasticMoment(rings, fy)
+	p.XPlastic = xp + p.X
+	for i := range rings {
+		rotatePolygons(rings[i], -math.Pi/2)
+	}
+
+	// torsion
+	mesh, err := GenerateMsh(m)
+	if err != nil {
+		return
+	}
+	MoveXOY(mesh, -p.X, -p.Y)
+	ts := triangles(*mesh)
+	g := make([]float64, len(ts))
+	for k, t := range ts {
+		xc, yc := t.center()
+		for i, r := range m.Regions {
+			if winding(rings[i], Point{X: xc, Y: yc}) != 0 {
+				g[k] = r.Material.G
+				break
+			}
+		}
+		if g[k] == 0 {
+			err = fmt.Errorf("triangle %d is outside of regions", k)
+			return
+		}
+	}
+	p.GJ, err = torsionStiffness(*mesh, ts, g)
+	return
+}
+
+// plasticMoment return location of plastic neutral axe parallel to axe X
+// and plastic moment for regions with strength fy
+func plasticMoment(rings [][][]Point, fy []float64) (y, m float64) {
+	var (
+		ymin = math.Inf(1)
+		ymax = math.Inf(-1)
+		half float64
+	)
+	for i, rs := range rings {
+		half += fy[i] * integrate(rs).A / 2
+		for _, r := range rs {
+			for _, p := range r {
+				ymin = math.Min(ymin, p.Y)
+				ymax = math.Max(ymax, p.Y)
+			}
+		}
+	}
+	// force above axe and first moment of areas above and below axe
+	above := func(y float64) (f, m float64) {
+		for i, rs := range rings {
+			rs = copyPolygons(rs)
+			movePolygons(rs, 0, -y)
+			all := integrate(rs)
+			up := integrate(clip(rs))
+			f += fy[i] * up.A
+			m += fy[i] * (up.Sx - (all.Sx - up.Sx))
+		}
+		return
+	}
+	y = neutral(ymin, ymax, half, func(y float64) float64 {
+		f, _ := above(y)
+		return f
+	})
+	_, m = above(y)
+	return
+}
+
+// torsionStiffness return torsional stiffness of mesh with shear moduli g
+// of triangles, see TorsionProperty
+func torsionStiffness(mesh msh.Msh, ts []triangle, g []float64) (gj float64, err error) {
+	k := newSparse(len(mesh.Nodes))
+	f := make([]float64, len(mesh.Nodes))
+	for n, t := range ts {
+		xc, yc := t.center()
+		for i := range t.index {
+			for j := range t.index {
+				k.add(t.index[i], t.index[j],
+					g[n]*t.area*(t.dx[i]*t.dx[j]+t.dy[i]*t.dy[j]))
+			}
+			f[t.index[i]] += g[n] * t.area * (yc*t.dx[i] - xc*t.dy[i])
+		}
+	}
+	for _, n := range fixed(len(mesh.Nodes), ts) {
+		k.fix(n)
+		f[n] = 0
+	}
+	w, err := k.solve(f)
+	if err != nil {
+		err = fmt.Errorf("warping function: %v", err)
+		return
+	}
+	for n, t := range ts {
+		xc, yc := t.center()
+		wx, wy := t.gradient(w)
+		gj += g[n] * (t.integral(t.x, t.x) + t.integral(t.y, t.y) +
+			t.area*(xc*wy-yc*wx))
+	}
+	return
+}
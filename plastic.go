@@ -0,0 +1,181 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+)
+
+// PlasticAxe is plastic neutral axe of homogeneous section. Axe is
+// located at angle Angle from axe X with offset D from center of section
+// in direction (-sin(Angle), cos(Angle)) of compressed fibres. Internal
+// force is for rigid-plastic stress Fy, moments are around center of
+// section, see StressResultant.
+type PlasticAxe struct {
+	Angle, D float64
+	StressResultant
+}
+
+// PlasticSurface is plastic interaction surface N-Mx-My as contours of
+// moments for equal steps of axial force from plastic tension to
+// plastic compression.
+type PlasticSurface struct {
+	Fy       float64
+	X, Y     float64 // center of section
+	NPlastic float64
+	Contours [][]PlasticAxe
+}
+
+func (ps PlasticSurface) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Plastic interaction surface\n")
+	fmt.Fprintf(w, "fy\t%s\tyield strength\n", efmt.Sprint(ps.Fy))
+	fmt.Fprintf(w, "X\t%s\tlocation center by axe X\n", efmt.Sprint(ps.X))
+	fmt.Fprintf(w, "Y\t%s\tlocation center by axe Y\n", efmt.Sprint(ps.Y))
+	fmt.Fprintf(w, "NPlastic\t%s\tPlastic axial force\n", efmt.Sprint(ps.NPlastic))
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Contour\tAngle\tD\tN\tMx\tMy\n")
+	for i, c := range ps.Contours {
+		for _, a := range c {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", i,
+				efmt.Sprint(a.Angle), efmt.Sprint(a.D),
+				efmt.Sprint(a.N), efmt.Sprint(a.Mx), efmt.Sprint(a.My))
+		}
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// plasticSection is contours of section moved to center
+type plasticSection struct {
+	rings  [][]Point
+	a      float64
+	fy     float64
+	xc, yc float64
+}
+
+func newPlasticSection(g Geor, fy float64) (ps plasticSection, err error) {
+	if fy <= 0 {
+		err = fmt.Errorf("yield strength is not positive: %e", fy)
+		return
+	}
+	if ps.rings, err = contours(g); err != nil {
+		return
+	}
+	pi := integrate(ps.rings)
+	if pi.A <= 0 {
+		err = fmt.Errorf("area is not positive: %e", pi.A)
+		return
+	}
+	ps.a, ps.fy = pi.A, fy
+	ps.xc, ps.yc = pi.Sy/pi.A, pi.Sx/pi.A
+	movePolygons(ps.rings, -ps.xc, -ps.yc)
+	return
+}
+
+// axe return plastic neutral axe for axial force n and angle. Offset of
+// axe is found by bisection for equal forces.
+func (ps plasticSection) axe(n, angle float64) (pa PlasticAxe, err error) {
+	np := ps.fy * ps.a
+	if np < math.Abs(n) {
+		err = fmt.Errorf("axial force %e is more than plastic resistance %e", n, np)
+		return
+	}
+	// axe of rotated contours is parallel to axe X
+	rs := copyPolygons(ps.rings)
+	rotatePolygons(rs, -angle)
+	dmin, dmax := math.Inf(1), math.Inf(-1)
+	for _, r := range rs {
+		for _, p := range r {
+			dmin = math.Min(dmin, p.Y)
+			dmax = math.Max(dmax, p.Y)
+		}
+	}
+	// compressed part of section above axe with offset d
+	above := func(d float64) [][]Point {
+		c := copyPolygons(rs)
+		movePolygons(c, 0, -d)
+		c = clip(c)
+		movePolygons(c, 0, d)
+		return c
+	}
+	// area of compressed part
+	ac := (n/ps.fy + ps.a) / 2
+	pa.Angle = angle
+	pa.D = neutral(dmin, dmax, ac, func(d float64) float64 {
+		return integrate(above(d)).A
+	})
+	c := above(pa.D)
+	rotatePolygons(c, angle)
+	pi := integrate(c)
+	// first moment of full section around center is zero
+	pa.N = ps.fy * (2*pi.A - ps.a)
+	pa.Mx = 2 * ps.fy * pi.Sx
+	pa.My = 2 * ps.fy * pi.Sy
+	return
+}
+
+// neutral return location of axe parallel to axe X between lo and hi,
+// where force above axe is equal to f. Force is decreasing function of
+// location, so location is found by bisection.
+func neutral(lo, hi, f float64, above func(y float64) float64) float64 {
+	tol := Eps * Eps * (hi - lo)
+	for iter := 0; iter < IterMax && tol < hi-lo; iter++ {
+		y := (lo + hi) / 2
+		if f < above(y) {
+			lo = y
+		} else {
+			hi = y
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// PlasticNeutral return plastic neutral axe of homogeneous section with
+// yield strength fy for axial force n, positive in compression, and angle
+// of axe from axe X. For zero axial force axe divides section on equal
+// areas.
+func PlasticNeutral(g Geor, fy, n, angle float64) (pa PlasticAxe, err error) {
+	ps, err := newPlasticSection(g, fy)
+	if err != nil {
+		return
+	}
+	return ps.axe(n, angle)
+}
+
+// InteractionPlastic return plastic interaction surface of homogeneous
+// section with yield strength fy. Surface has levels+1 contours with
+// equal steps of axial force from -NPlastic to NPlastic, each contour
+// has points for angles of neutral axe with step 2*pi/angles.
+func InteractionPlastic(g Geor, fy float64, levels, angles int) (s PlasticSurface, err error) {
+	if levels < 1 || angles < 1 {
+		err = fmt.Errorf("not valid amount of levels or angles: %d, %d", levels, angles)
+		return
+	}
+	ps, err := newPlasticSection(g, fy)
+	if err != nil {
+		return
+	}
+	s.Fy = fy
+	s.X, s.Y = ps.xc, ps.yc
+	s.NPlastic = fy * ps.a
+	for i := 0; i <= levels; i++ {
+		n := s.NPlastic * (2*float64(i)/float64(levels) - 1)
+		var c []PlasticAxe
+		for k := 0; k < angles; k++ {
+			a := 2 * math.Pi * float64(k) / float64(angles)
+			pa, err := ps.axe(n, a)
+			if err != nil {
+				return s, err
+			}
+			c = append(c, pa)
+		}
+		s.Contours = append(s.Contours, c)
+	}
+	return
+}
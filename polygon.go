@@ -0,0 +1,403 @@
+package section
+
+import (
+	"fmt"
+	"math"
+)
+
+// Point is point of section contour
+type Point struct {
+	X, Y float64
+}
+
+// Polygoner is section with straight edges only. Property of section
+// is calculated exactly by contours without triangle mesh.
+//
+// Outer contours of section are counterclockwise, contours of holes are
+// clockwise.
+type Polygoner interface {
+	Polygons() [][]Point
+}
+
+// Integrals on polygon by Green's theorem:
+//
+//	A   = 1/2  * sum(c)
+//	Sx  = 1/6  * sum((y1+y2)*c)                    = integral(y,dA)
+//	Sy  = 1/6  * sum((x1+x2)*c)                    = integral(x,dA)
+//	Jxx = 1/12 * sum((y1^2+y1*y2+y2^2)*c)          = integral(y^2,dA)
+//	Jyy = 1/12 * sum((x1^2+x1*x2+x2^2)*c)          = integral(x^2,dA)
+//	Jxy = 1/24 * sum((x1*y2+2*x1*y1+2*x2*y2+x2*y1)*c) = integral(x*y,dA)
+//	c   = x1*y2 - x2*y1
+//
+// See https://en.wikipedia.org/wiki/Second_moment_of_area#Any_polygon
+type polygonIntegral struct {
+	A, Sx, Sy, Jxx, Jyy, Jxy float64
+}
+
+func integrate(rings [][]Point) (pi polygonIntegral) {
+	for _, r := range rings {
+		for i := range r {
+			var (
+				p1 = r[i]
+				p2 = r[(i+1)%len(r)]
+				c  = p1.X*p2.Y - p2.X*p1.Y
+			)
+			pi.A += c / 2.0
+			pi.Sx += (p1.Y + p2.Y) * c / 6.0
+			pi.Sy += (p1.X + p2.X) * c / 6.0
+			pi.Jxx += (p1.Y*p1.Y + p1.Y*p2.Y + p2.Y*p2.Y) * c / 12.0
+			pi.Jyy += (p1.X*p1.X + p1.X*p2.X + p2.X*p2.X) * c / 12.0
+			pi.Jxy += (p1.X*p2.Y + 2*p1.X*p1.Y + 2*p2.X*p2.Y + p2.X*p1.Y) * c / 24.0
+		}
+	}
+	return
+}
+
+// clip return part of contours above axe X.
+//
+// See https://en.wikipedia.org/wiki/Sutherland%E2%80%93Hodgman_algorithm
+func clip(rings [][]Point) (res [][]Point) {
+	for _, r := range rings {
+		var out []Point
+		for i := range r {
+			a, b := r[i], r[(i+1)%len(r)]
+			if 0 <= a.Y {
+				out = append(out, a)
+			}
+			if (a.Y < 0 && 0 < b.Y) || (b.Y < 0 && 0 < a.Y) {
+				out = append(out, Point{
+					X: a.X + (b.X-a.X)*(0-a.Y)/(b.Y-a.Y),
+					Y: 0,
+				})
+			}
+		}
+		if 2 < len(out) {
+			res = append(res, out)
+		}
+	}
+	return
+}
+
+// onSegment return true for point `p` on segment (a,b). Points must be
+// collinear.
+func onSegment(a, b, p Point) bool {
+	return math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+}
+
+// intersect return true for segments (a,b) and (c,d) with common points
+func intersect(a, b, c, d Point) bool {
+	var (
+		o1 = orient(a, b, c)
+		o2 = orient(a, b, d)
+		o3 = orient(c, d, a)
+		o4 = orient(c, d, b)
+	)
+	if ((0 < o1 && o2 < 0) || (o1 < 0 && 0 < o2)) &&
+		((0 < o3 && o4 < 0) || (o3 < 0 && 0 < o4)) {
+		return true
+	}
+	return (o1 == 0 && onSegment(a, b, c)) ||
+		(o2 == 0 && onSegment(a, b, d)) ||
+		(o3 == 0 && onSegment(c, d, a)) ||
+		(o4 == 0 && onSegment(c, d, b))
+}
+
+// intersection return edges with intersection. Edge is index of contour
+// and index of first point of edge. Adjacent edges of contour have
+// common point and it is not intersection.
+func intersection(rings [][]Point) (e1, e2 [2]int, ok bool) {
+	for r1 := range rings {
+		for i := range rings[r1] {
+			for r2 := r1; r2 < len(rings); r2++ {
+				start := 0
+				if r1 == r2 {
+					start = i + 1
+				}
+				for j := start; j < len(rings[r2]); j++ {
+					n := len(rings[r1])
+					if r1 == r2 && (j == i+1 || (i == 0 && j == n-1)) {
+						// adjacent edges with overlapping
+						a, b, c := rings[r1][i], rings[r1][(i+1)%n], rings[r1][(i+2)%n]
+						if j != i+1 {
+							a, b, c = rings[r1][j], rings[r1][0], rings[r1][1]
+						}
+						if orient(a, b, c) == 0 &&
+							(b.X-a.X)*(c.X-b.X)+(b.Y-a.Y)*(c.Y-b.Y) < 0 {
+							return [2]int{r1, i}, [2]int{r2, j}, true
+						}
+						continue
+					}
+					if intersect(
+						rings[r1][i], rings[r1][(i+1)%n],
+						rings[r2][j], rings[r2][(j+1)%len(rings[r2])],
+					) {
+						return [2]int{r1, i}, [2]int{r2, j}, true
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// overlap return true for regions inside contours with common area.
+// Outer contours are counterclockwise, holes are clockwise. Regions with
+// common edges or points are not overlapped.
+func overlap(r1, r2 [][]Point) bool {
+	// side return sign of orientation with tolerance for collinear points
+	side := func(a, b, c Point) int {
+		o := orient(a, b, c)
+		if math.Abs(o) <= Eps*Eps*distance(a, b)*(distance(a, c)+distance(b, c)) {
+			return 0
+		}
+		if o < 0 {
+			return -1
+		}
+		return 1
+	}
+	// crossing of edges
+	for _, ra := range r1 {
+		for i := range ra {
+			a, b := ra[i], ra[(i+1)%len(ra)]
+			for _, rb := range r2 {
+				for j := range rb {
+					c, d := rb[j], rb[(j+1)%len(rb)]
+					if side(a, b, c)*side(a, b, d) < 0 &&
+						side(c, d, a)*side(c, d, b) < 0 {
+						return true
+					}
+				}
+			}
+		}
+	}
+	// points near middle of edges inside both regions
+	inside := func(rs, other [][]Point) bool {
+		for _, r := range rs {
+			for i := range r {
+				a, b := r[i], r[(i+1)%len(r)]
+				p := Point{
+					X: (a.X+b.X)/2 - Eps*(b.Y-a.Y),
+					Y: (a.Y+b.Y)/2 + Eps*(b.X-a.X),
+				}
+				if winding(rs, p) != 0 && winding(other, p) != 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return inside(r1, r2) || inside(r2, r1)
+}
+
+// movePolygons move contours on (dx,dy)
+func movePolygons(rings [][]Point, dx, dy float64) {
+	for _, r := range rings {
+		for i := range r {
+			r[i].X += dx
+			r[i].Y += dy
+		}
+	}
+}
+
+// rotatePolygons rotate contours around origin on angle `a`
+func rotatePolygons(rings [][]Point, a float64) {
+	sin, cos := math.Sin(a), math.Cos(a)
+	for _, r := range rings {
+		for i := range r {
+			x, y := r[i].X, r[i].Y
+			r[i].X = x*cos - y*sin
+			r[i].Y = x*sin + y*cos
+		}
+	}
+}
+
+// equalArea return location of axe parallel to axe X with equal areas
+// above and below
+func equalArea(rings [][]Point) (y float64) {
+	var (
+		half = integrate(rings).A / 2
+		ymin = math.Inf(1)
+		ymax = math.Inf(-1)
+	)
+	for _, r := range rings {
+		for _, p := range r {
+			ymin = math.Min(ymin, p.Y)
+			ymax = math.Max(ymax, p.Y)
+		}
+	}
+	// area above axe is decreasing function of location
+	for iter := 0; iter < IterMax; iter++ {
+		y = (ymin + ymax) / 2
+		rs := copyPolygons(rings)
+		movePolygons(rs, 0, -y)
+		if half < integrate(clip(rs)).A {
+			ymin = y
+		} else {
+			ymax = y
+		}
+		if ymax-ymin < Eps*Eps {
+			break
+		}
+	}
+	return (ymin + ymax) / 2
+}
+
+// copyPolygons return copy of contours
+func copyPolygons(rings [][]Point) (res [][]Point) {
+	for _, r := range rings {
+		res = append(res, append([]Point{}, r...))
+	}
+	return
+}
+
+// contours return contours of section with positive area. Arcs of
+// boundary are replaced by polylines. Result is nil for section
+// without contours.
+func contours(g Geor) (rings [][]Point, err error) {
+	if v, ok := g.(interface{ Validate() error }); ok {
+		if err = v.Validate(); err != nil {
+			return
+		}
+	}
+	switch v := g.(type) {
+	case Polygoner:
+		rings = copyPolygons(v.Polygons())
+	case Bounder:
+		rings = boundaryPolygons(v.Boundaries())
+	default:
+		return
+	}
+	for _, r := range rings {
+		if len(r) < 3 {
+			err = fmt.Errorf("contour have less 3 points")
+			return
+		}
+	}
+	if integrate(rings).A < 0 {
+		for _, r := range rings {
+			for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+				r[i], r[j] = r[j], r[i]
+			}
+		}
+	}
+	if a := integrate(rings).A; a <= 0 {
+		err = fmt.Errorf("Area is not valid: %e", a)
+	}
+	return
+}
+
+// CalculatePolygons calculate bending property by contours of section
+func (b *BendingProperty) CalculatePolygons(rings [][]Point) {
+	rings = copyPolygons(rings)
+	A := integrate(rings).A
+	calc := func() (j, h, w, r, wpl, s, yp float64) {
+		pi := integrate(rings)
+		j = pi.Jxx
+		for _, r := range rings {
+			for _, p := range r {
+				h = math.Max(h, math.Abs(p.Y))
+			}
+		}
+		w = j / h
+		r = math.Sqrt(j / A)
+		// first moment of area above and below axe X
+		up := integrate(clip(rings)).Sx
+		s = up - (pi.Sx - up)
+		// first moment of area above and below plastic neutral axe
+		yp = equalArea(rings)
+		rs := copyPolygons(rings)
+		movePolygons(rs, 0, -yp)
+		up = integrate(clip(rs)).Sx
+		wpl = up - (integrate(rs).Sx - up)
+		return
+	}
+
+	const perp float64 = math.Pi / 2.0 // 90 degree
+
+	// distances to extreme fibres
+	extreme := func() (top, bottom float64) {
+		top, bottom = math.Inf(-1), math.Inf(-1)
+		for _, r := range rings {
+			for _, p := range r {
+				top = math.Max(top, p.Y)
+				bottom = math.Max(bottom, -p.Y)
+			}
+		}
+		return
+	}
+
+	b.Jxx, b.Ymax, b.Wx, b.Rx, b.WxPlastic, b.Sx, b.YPlasticNeutral = calc()
+	b.YTop, b.YBottom = extreme()
+	rotatePolygons(rings, perp)
+	b.Jyy, b.Xmax, b.Wy, b.Ry, b.WyPlastic, b.Sy, b.XPlasticNeutral = calc()
+	b.XRight, b.XLeft = extreme()
+	rotatePolygons(rings, -perp)
+	b.Jxy = integrate(rings).Jxy
+	b.fibres()
+	b.Jo = b.Jxx + b.Jyy
+	b.Ro = math.Sqrt(b.Jo / A)
+}
+
+// meshSize return size of triangles for polygon mesh. Thickness of
+// thin-walled section is approximately 2*A/perimeter.
+func meshSize(rings [][]Point) float64 {
+	var perimeter float64
+	for _, r := range rings {
+		for i := range r {
+			perimeter += distance(r[i], r[(i+1)%len(r)])
+		}
+	}
+	return 2 * integrate(rings).A / perimeter / 6.0
+}
+
+// CalculateBending return area, center, bending and principal properties
+// of section by contours only, without triangle mesh. Torsion and shear
+// properties are not calculated.
+func CalculateBending(g Geor) (p *Property, err error) {
+	rings, err := contours(g)
+	if err != nil {
+		return
+	}
+	if rings == nil {
+		err = fmt.Errorf("section without contours: %s", g.GetName())
+		return
+	}
+	return calculatePolygons(g.GetName(), rings), nil
+}
+
+// calculatePolygons return area, center, bending and principal
+// properties by contours. Contours are moved to the center.
+func calculatePolygons(name string, rings [][]Point) *Property {
+	pr := Property{Name: name}
+	pi := integrate(rings)
+	pr.A, pr.X, pr.Y = pi.A, pi.Sy/pi.A, pi.Sx/pi.A
+	pr.AtBasePoint.CalculatePolygons(rings)
+	movePolygons(rings, -pr.X, -pr.Y)
+	pr.AtCenterPoint.CalculatePolygons(rings)
+	var vertices []Point
+	for _, r := range rings {
+		vertices = append(vertices, r...)
+	}
+	pr.Principal.calculate(vertices, pr.AtCenterPoint, pr.A, pr.X, pr.Y)
+	pr.Alpha = pr.AtCenterPoint.Alpha()
+	rs := copyPolygons(rings)
+	rotatePolygons(rs, -pr.Alpha)
+	pr.OnSectionAxe.CalculatePolygons(rs)
+	return &pr
+}
+
+// Polygons of PlateGroup is rectangles of plates
+func (pg PlateGroup) Polygons() [][]Point {
+	return boundaryPolygons(pg.Boundaries())
+}
+
+// Polygons of Rectangle
+func (r Rectangle) Polygons() [][]Point {
+	return boundaryPolygons(r.Boundaries())
+}
+
+// Polygons of Tsection
+func (t Tsection) Polygons() [][]Point {
+	return boundaryPolygons(t.Boundaries())
+}
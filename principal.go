@@ -0,0 +1,139 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+	"github.com/Konstantin8105/msh"
+)
+
+// PrincipalProperty is property of section on principal axes U-V with
+// origin at the center of section:
+//
+//	axe U - axe with maximal moment of inertia
+//	axe V - axe with minimal moment of inertia
+//
+//	Ju,v = (Jx+Jy)/2 +- sqrt(((Jx-Jy)/2)^2+Jxy^2)
+//	tan(2*Alpha) = -2.0*Jxy/(Jx-Jy)
+//
+// For angles with long leg along axe Y the value TanAlpha is tan(alpha)
+// of standards EN 10056-1 and GOST 8510.
+type PrincipalProperty struct {
+	Alpha    float64 // angle from axe X to axe U, -pi/2 < Alpha <= pi/2
+	TanAlpha float64 // tan(Alpha)
+
+	Ju, Ru, Wu float64 // moment of inertia, radius and elastic modulus by axe U
+	Jv, Rv, Wv float64 // moment of inertia, radius and elastic modulus by axe V
+
+	// Elastic moduli for extreme fibres
+	WuTop, WuBottom float64 // by axe U for fibres Top and Bottom
+	WvRight, WvLeft float64 // by axe V for fibres Right and Left
+
+	// Extreme fibres of section
+	Top    Fibre // maximal distance from axe U on positive side of axe V
+	Bottom Fibre // maximal distance from axe U on negative side of axe V
+	Right  Fibre // maximal distance from axe V on positive side of axe U
+	Left   Fibre // maximal distance from axe V on negative side of axe U
+}
+
+// Fibre is point of section
+type Fibre struct {
+	X, Y float64 // coordinates in base system
+	U, V float64 // coordinates on principal axes
+}
+
+func (f Fibre) String() string {
+	return fmt.Sprintf("U = %s, V = %s, X = %s, Y = %s",
+		efmt.Sprint(f.U), efmt.Sprint(f.V),
+		efmt.Sprint(f.X), efmt.Sprint(f.Y))
+}
+
+func (pr PrincipalProperty) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Alpha\t%s\tAngle from axe X to axe U\n", efmt.Sprint(pr.Alpha))
+	fmt.Fprintf(w, "TanAlpha\t%s\tTangent of angle Alpha\n", efmt.Sprint(pr.TanAlpha))
+	fmt.Fprintf(w, "By axe\tU\t.\n")
+	fmt.Fprintf(w, "Ju\t%s\tMaximal moment inertia by axe U\n", efmt.Sprint(pr.Ju))
+	fmt.Fprintf(w, "Ru\t%s\tRadius inertia around axe U\n", efmt.Sprint(pr.Ru))
+	fmt.Fprintf(w, "Wu\t%s\tElastic moment resistance around axe U\n", efmt.Sprint(pr.Wu))
+	fmt.Fprintf(w, "By axe\tV\t.\n")
+	fmt.Fprintf(w, "Jv\t%s\tMinimal moment inertia by axe V\n", efmt.Sprint(pr.Jv))
+	fmt.Fprintf(w, "Rv\t%s\tRadius inertia around axe V\n", efmt.Sprint(pr.Rv))
+	fmt.Fprintf(w, "Wv\t%s\tElastic moment resistance around axe V\n", efmt.Sprint(pr.Wv))
+	fmt.Fprintf(w, "WuTop\t%s\tElastic moment resistance around axe U for fibre Top\n", efmt.Sprint(pr.WuTop))
+	fmt.Fprintf(w, "WuBottom\t%s\tElastic moment resistance around axe U for fibre Bottom\n", efmt.Sprint(pr.WuBottom))
+	fmt.Fprintf(w, "WvRight\t%s\tElastic moment resistance around axe V for fibre Right\n", efmt.Sprint(pr.WvRight))
+	fmt.Fprintf(w, "WvLeft\t%s\tElastic moment resistance around axe V for fibre Left\n", efmt.Sprint(pr.WvLeft))
+	fmt.Fprintf(w, "By axe\tExtreme fibres\t.\n")
+	fmt.Fprintf(w, "Top\t%s\tMaximal V\n", pr.Top)
+	fmt.Fprintf(w, "Bottom\t%s\tMinimal V\n", pr.Bottom)
+	fmt.Fprintf(w, "Right\t%s\tMaximal U\n", pr.Right)
+	fmt.Fprintf(w, "Left\t%s\tMinimal U\n", pr.Left)
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// Calculate principal property by bending property at the center of
+// section and area. Mesh must be located at the center of section (xc,yc).
+func (pr *PrincipalProperty) Calculate(mesh msh.Msh, b BendingProperty, area, xc, yc float64) {
+	ps := make([]Point, len(mesh.Nodes))
+	for i, n := range mesh.Nodes {
+		ps[i] = Point{X: n.Coord[0], Y: n.Coord[1]}
+	}
+	pr.calculate(ps, b, area, xc, yc)
+}
+
+// calculate principal property with extreme fibres from points located
+// at the center of section
+func (pr *PrincipalProperty) calculate(ps []Point, b BendingProperty, area, xc, yc float64) {
+	pr.Alpha = math.Atan2(-2.0*b.Jxy, b.Jxx-b.Jyy) / 2.0
+	if pr.Alpha <= -math.Pi/2.0 {
+		pr.Alpha += math.Pi
+	}
+	pr.TanAlpha = math.Tan(pr.Alpha)
+	var (
+		c = (b.Jxx + b.Jyy) / 2.0
+		r = math.Hypot((b.Jxx-b.Jyy)/2.0, b.Jxy)
+	)
+	pr.Ju, pr.Jv = c+r, c-r
+	pr.Ru, pr.Rv = math.Sqrt(pr.Ju/area), math.Sqrt(pr.Jv/area)
+
+	sin, cos := math.Sin(pr.Alpha), math.Cos(pr.Alpha)
+	for i, n := range ps {
+		var (
+			x = n.X
+			y = n.Y
+			f = Fibre{
+				X: x + xc,
+				Y: y + yc,
+				U: x*cos + y*sin,
+				V: -x*sin + y*cos,
+			}
+		)
+		if i == 0 {
+			pr.Top, pr.Bottom, pr.Right, pr.Left = f, f, f, f
+			continue
+		}
+		if pr.Top.V < f.V {
+			pr.Top = f
+		}
+		if f.V < pr.Bottom.V {
+			pr.Bottom = f
+		}
+		if pr.Right.U < f.U {
+			pr.Right = f
+		}
+		if f.U < pr.Left.U {
+			pr.Left = f
+		}
+	}
+	pr.Wu = modulus(pr.Ju, math.Max(pr.Top.V, -pr.Bottom.V))
+	pr.Wv = modulus(pr.Jv, math.Max(pr.Right.U, -pr.Left.U))
+	pr.WuTop, pr.WuBottom = modulus(pr.Ju, pr.Top.V), modulus(pr.Ju, -pr.Bottom.V)
+	pr.WvRight, pr.WvLeft = modulus(pr.Jv, pr.Right.U), modulus(pr.Jv, -pr.Left.U)
+}
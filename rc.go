@@ -0,0 +1,351 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+)
+
+// Bar is reinforcement bar with center (X,Y)
+type Bar struct {
+	X, Y     float64
+	Diameter float64
+}
+
+// Area of bar
+func (b Bar) Area() float64 {
+	return math.Pi * b.Diameter * b.Diameter / 4
+}
+
+// RC is reinforced concrete section. Section of concrete is Bounder,
+// for example Rectangle, Tsection or Polygon. Bars are inside of
+// concrete.
+//
+// Example of rectangle section 300x500 with 4 bars:
+//
+//	RC{
+//		Section:  Rectangle{H: 0.5, Thk: 0.3},
+//		Bars:     []Bar{{-0.1, 0.05, 0.02}, {0.1, 0.05, 0.02}, {-0.1, 0.45, 0.02}, {0.1, 0.45, 0.02}},
+//		Concrete: NewConcrete(30e6),
+//		Rebar:    NewRebar(500e6),
+//	}
+type RC struct {
+	Name     string
+	Section  Geor
+	Bars     []Bar
+	Concrete Concrete
+	Rebar    Rebar
+}
+
+func (rc RC) GetName() string {
+	if rc.Name != "" {
+		return rc.Name
+	}
+	name := "Undefined"
+	if rc.Section != nil {
+		name = rc.Section.GetName()
+	}
+	var as float64
+	for _, b := range rc.Bars {
+		as += b.Area()
+	}
+	return fmt.Sprintf("RC %s, %s, %d bars As%.2f cm2, %s",
+		name, rc.Concrete.Name, len(rc.Bars), as*1e4, rc.Rebar.Name)
+}
+
+func (rc RC) Geo(prec float64) string {
+	return rc.Section.Geo(prec)
+}
+
+func (rc RC) Validate() error {
+	if err := validateBounder(rc.Section); err != nil {
+		return err
+	}
+	rings, err := contours(rc.Section)
+	if err != nil {
+		return err
+	}
+	for i, b := range rc.Bars {
+		if b.Diameter <= 0 {
+			return fmt.Errorf("bar %d: diameter is not positive", i)
+		}
+		if winding(rings, Point{X: b.X, Y: b.Y}) == 0 {
+			return fmt.Errorf("bar %d is outside of concrete", i)
+		}
+	}
+	if err := rc.Concrete.Validate(); err != nil {
+		return err
+	}
+	return rc.Rebar.Validate()
+}
+
+// Boundaries of RC is boundaries of concrete
+func (rc RC) Boundaries() []Boundary {
+	return transformed(rc.Section, false, 0, 0, 0)
+}
+
+// Transformed is property of section at the center (X,Y) in units of
+// concrete
+type Transformed struct {
+	A, X, Y       float64
+	Jxx, Jyy, Jxy float64
+}
+
+func (t Transformed) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "A\t%s\tArea\n", efmt.Sprint(t.A))
+	fmt.Fprintf(w, "X\t%s\tlocation center by axe X\n", efmt.Sprint(t.X))
+	fmt.Fprintf(w, "Y\t%s\tlocation center by axe Y\n", efmt.Sprint(t.Y))
+	fmt.Fprintf(w, "Jxx\t%s\tMoment inertia around axe X\n", efmt.Sprint(t.Jxx))
+	fmt.Fprintf(w, "Jyy\t%s\tMoment inertia around axe Y\n", efmt.Sprint(t.Jyy))
+	fmt.Fprintf(w, "Jxy\t%s\tProduct moment inertia\n", efmt.Sprint(t.Jxy))
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// Cracked is property of cracked section without concrete in tension.
+// Neutral axe is located on Axe, area A and moment inertia J around
+// neutral axe are in units of concrete.
+type Cracked struct {
+	Axe  float64
+	A, J float64
+}
+
+// RCProperty is property of reinforced concrete section. Modular ratio
+// is Es/Ecm. Cracked section CrackedX is for moment with compression of
+// fibres with positive Y, CrackedY is for moment with compression of
+// fibres with positive X.
+type RCProperty struct {
+	Ratio              float64 // modular ratio
+	As                 float64 // area of bars
+	Gross, Uncracked   Transformed
+	CrackedX, CrackedY Cracked
+}
+
+func (p RCProperty) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Ratio\t%s\tModular ratio Es/Ecm\n", efmt.Sprint(p.Ratio))
+	fmt.Fprintf(w, "As\t%s\tArea of bars\n", efmt.Sprint(p.As))
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Gross section\n%s", p.Gross)
+	fmt.Fprintf(w, "Uncracked transformed section\n%s", p.Uncracked)
+	fmt.Fprintf(w, "Cracked section\n")
+	fmt.Fprintf(w, "Y\t%s\tlocation of neutral axe for moment around axe X\n", efmt.Sprint(p.CrackedX.Axe))
+	fmt.Fprintf(w, "A\t%s\tArea\n", efmt.Sprint(p.CrackedX.A))
+	fmt.Fprintf(w, "Jxx\t%s\tMoment inertia around neutral axe\n", efmt.Sprint(p.CrackedX.J))
+	fmt.Fprintf(w, "X\t%s\tlocation of neutral axe for moment around axe Y\n", efmt.Sprint(p.CrackedY.Axe))
+	fmt.Fprintf(w, "A\t%s\tArea\n", efmt.Sprint(p.CrackedY.A))
+	fmt.Fprintf(w, "Jyy\t%s\tMoment inertia around neutral axe\n", efmt.Sprint(p.CrackedY.J))
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// CalculateRC return gross, uncracked and cracked property of reinforced
+// concrete section. Bars are replaced by concrete with modular ratio,
+// inertia of bars around own centers is ignored.
+func CalculateRC(rc RC) (p RCProperty, err error) {
+	if err = rc.Validate(); err != nil {
+		return
+	}
+	rings, err := contours(rc.Section)
+	if err != nil {
+		return
+	}
+	n := rc.Rebar.Es / rc.Concrete.Ecm
+	p.Ratio = n
+	pi := integrate(rings)
+	p.Gross = transformedSection(pi, nil, 0)
+	p.Uncracked = transformedSection(pi, rc.Bars, n-1)
+	for _, b := range rc.Bars {
+		p.As += b.Area()
+	}
+	p.CrackedX = cracked(rings, rc.Bars, n)
+	rs := copyPolygons(rings)
+	rotatePolygons(rs, math.Pi/2)
+	bars := make([]Bar, len(rc.Bars))
+	for i, b := range rc.Bars {
+		bars[i] = Bar{X: -b.Y, Y: b.X, Diameter: b.Diameter}
+	}
+	p.CrackedY = cracked(rs, bars, n)
+	return
+}
+
+// transformedSection return property of concrete with integrals pi and
+// bars with area multiplied by n
+func transformedSection(pi polygonIntegral, bars []Bar, n float64) (t Transformed) {
+	for _, b := range bars {
+		a := n * b.Area()
+		pi.A += a
+		pi.Sx += a * b.Y
+		pi.Sy += a * b.X
+		pi.Jxx += a * b.Y * b.Y
+		pi.Jyy += a * b.X * b.X
+		pi.Jxy += a * b.X * b.Y
+	}
+	t.A = pi.A
+	t.X, t.Y = pi.Sy/pi.A, pi.Sx/pi.A
+	t.Jxx = pi.Jxx - t.A*t.Y*t.Y
+	t.Jyy = pi.Jyy - t.A*t.X*t.X
+	t.Jxy = pi.Jxy - t.A*t.X*t.Y
+	return
+}
+
+// cracked return cracked section for compression of concrete above
+// neutral axe parallel to axe X. Bars in compression are replaced by
+// concrete with ratio n-1, bars in tension with ratio n.
+func cracked(rings [][]Point, bars []Bar, n float64) (c Cracked) {
+	// first moment of area around axe y
+	moment := func(y float64) (s float64, pi polygonIntegral) {
+		rs := copyPolygons(rings)
+		movePolygons(rs, 0, -y)
+		pi = integrate(clip(rs))
+		s = pi.Sx
+		for _, b := range bars {
+			r := n
+			if y < b.Y {
+				r = n - 1
+			}
+			s += r * b.Area() * (b.Y - y)
+		}
+		return
+	}
+	var (
+		ymin = math.Inf(1)
+		ymax = math.Inf(-1)
+	)
+	for _, r := range rings {
+		for _, p := range r {
+			ymin = math.Min(ymin, p.Y)
+			ymax = math.Max(ymax, p.Y)
+		}
+	}
+	// first moment is decreasing function of location
+	for iter := 0; iter < IterMax; iter++ {
+		y := (ymin + ymax) / 2
+		if s, _ := moment(y); 0 < s {
+			ymin = y
+		} else {
+			ymax = y
+		}
+		if ymax-ymin < Eps*Eps {
+			break
+		}
+	}
+	c.Axe = (ymin + ymax) / 2
+	_, pi := moment(c.Axe)
+	c.A, c.J = pi.A, pi.Jxx
+	for _, b := range bars {
+		r := n
+		if c.Axe < b.Y {
+			r = n - 1
+		}
+		a := r * b.Area()
+		c.A += a
+		c.J += a * (b.Y - c.Axe) * (b.Y - c.Axe)
+	}
+	return
+}
+
+// InteractionRC return points of N-Mx-My interaction surface of
+// reinforced concrete section by EN 1992-1-1 section 6.1, see
+// StressResultant. Moments are around center of gross section. Strain planes of ultimate limit state
+// are rotated around pivots of ultimate strains of bars, compressed
+// concrete fibre and concrete at pure compression for `angles`
+// directions of neutral axe, with `steps` points for each pivot.
+func InteractionRC(rc RC, angles, steps int) (fs []StressResultant, err error) {
+	if err = rc.Validate(); err != nil {
+		return
+	}
+	if angles < 1 || steps < 1 {
+		err = fmt.Errorf("not valid amount of angles or steps: %d, %d", angles, steps)
+		return
+	}
+	rings, err := contours(rc.Section)
+	if err != nil {
+		return
+	}
+	pi := integrate(rings)
+	xc, yc := pi.Sy/pi.A, pi.Sx/pi.A
+	mesh, err := GenerateMsh(rc.Section)
+	if err != nil {
+		return
+	}
+	MoveXOY(mesh, -xc, -yc)
+	movePolygons(rings, -xc, -yc)
+	ts := triangles(*mesh)
+	var (
+		c      = rc.Concrete
+		s      = rc.Rebar
+		epsc2  = c.Epsc2
+		epscu2 = c.Epscu2
+		epsud  = s.Epsud
+	)
+	force := func(p plane) StressResultant {
+		f := stresses(ts, p, c)
+		for _, b := range rc.Bars {
+			x, y := b.X-xc, b.Y-yc
+			e := p.strain(x, y)
+			v := b.Area() * (s.Stress(e) - c.Stress(e))
+			f.N += v
+			f.Mx += v * y
+			f.My += v * x
+		}
+		return f
+	}
+	for k := 0; k < angles; k++ {
+		var (
+			a       = 2 * math.Pi * float64(k) / float64(angles)
+			ux, uy  = math.Cos(a), math.Sin(a)
+			dmax    = math.Inf(-1) // compressed fibre of concrete
+			dmin    = math.Inf(1)  // tension fibre of concrete
+			ds      = math.Inf(1)  // tension bar
+			hasBars = 0 < len(rc.Bars)
+		)
+		for _, r := range rings {
+			for _, p := range r {
+				d := p.X*ux + p.Y*uy
+				dmax = math.Max(dmax, d)
+				dmin = math.Min(dmin, d)
+			}
+		}
+		for _, b := range rc.Bars {
+			ds = math.Min(ds, (b.X-xc)*ux+(b.Y-yc)*uy)
+		}
+		if !hasBars {
+			ds = dmin
+		}
+		h := dmax - dmin
+		// strain plane by strain `et` on compressed fibre and curvature
+		add := func(et, k float64) {
+			// strain = et - k*(dmax - d)
+			fs = append(fs, force(plane{E0: et - k*dmax, Kx: k * ux, Ky: k * uy}))
+		}
+		// pivot of bars: from pure tension to ultimate strain of concrete
+		for i := 0; i < steps; i++ {
+			et := -epsud + (epscu2+epsud)*float64(i)/float64(steps)
+			add(et, (et+epsud)/(dmax-ds))
+		}
+		// pivot of compressed fibre: from ultimate strain of bars to zero
+		// strain of tension fibre
+		eb := epscu2 - (epscu2+epsud)*(dmax-dmin)/(dmax-ds)
+		for i := 0; i < steps; i++ {
+			e := eb * (1 - float64(i)/float64(steps))
+			add(epscu2, (epscu2-e)/h)
+		}
+		// pivot at depth (1-epsc2/epscu2)*h: to pure compression
+		dp := dmax - (1-epsc2/epscu2)*h
+		for i := 0; i <= steps; i++ {
+			e := epsc2 * float64(i) / float64(steps)
+			k := (epsc2 - e) / (dp - dmin)
+			add(epsc2+k*(dmax-dp), k)
+		}
+	}
+	return
+}
@@ -3,9 +3,9 @@ package section
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"sync"
 	"text/tabwriter"
-	"text/template"
 
 	"github.com/Konstantin8105/efmt"
 )
@@ -46,15 +46,42 @@ func GetList() (list []Geor) {
 	for i := range Angles {
 		list = append(list, Angles[i])
 	}
+	for i := range UnequalAngles {
+		list = append(list, UnequalAngles[i])
+	}
 	for i := range Isections {
 		list = append(list, Isections[i])
 	}
 	for i := range UPNs {
 		list = append(list, UPNs[i])
 	}
+	for i := range DoubleUPNs {
+		list = append(list, DoubleUPNs[i])
+	}
 	for i := range Rectangles {
 		list = append(list, Rectangles[i])
 	}
+	for i := range CHSs {
+		list = append(list, CHSs[i])
+	}
+	for i := range RHSs {
+		list = append(list, RHSs[i])
+	}
+	for i := range SHSs {
+		list = append(list, SHSs[i])
+	}
+	for i := range LippedChannels {
+		list = append(list, LippedChannels[i])
+	}
+	for i := range LippedZs {
+		list = append(list, LippedZs[i])
+	}
+	for i := range Sigmas {
+		list = append(list, Sigmas[i])
+	}
+	for i := range Hats {
+		list = append(list, Hats[i])
+	}
 	return
 }
 
@@ -105,7 +132,6 @@ var Angles = []Angle{
 	{"L50x5", 0.050, 0.005, 0.007, 0.0035},
 	{"L60x6", 0.060, 0.006, 0.008, 0.0040},
 	{"L63x6", 0.063, 0.006, 0.007, 0.0040},
-	{"L63x6", 0.063, 0.006, 0.008, 0.0040},
 	{"L70x7", 0.070, 0.007, 0.009, 0.0045},
 	{"L75x7", 0.075, 0.007, 0.008, 0.0045},
 	{"L75x8", 0.075, 0.008, 0.009, 0.0045},
@@ -117,62 +143,93 @@ var Angles = []Angle{
 }
 
 func (a Angle) Geo(prec float64) string {
-	// TODO: use text/template
-	var geo string
-
-	geo += fmt.Sprintf("b = %.5f;\n", a.Width)
-	geo += fmt.Sprintf("thk = %.5f;\n", a.Thk)
-	geo += fmt.Sprintf("r1 = %.5f;\n", a.Radius1)
-	geo += fmt.Sprintf("r2 = %.5f;\n", a.Radius2)
-	geo += fmt.Sprintf("Lc = %.5f;\n", a.Thk/2)
-	geo += fmt.Sprintf("Lc2 = %.5f;\n", prec)
-
-	geo += `
-    Point(000) = {+0.0000,+0.0000,+0.0000,Lc};
-    Point(001) = {b      ,+0.0000,+0.0000,Lc};
-    Point(002) = {b      ,thk-r2 ,+0.0000,Lc2};
-    Point(003) = {b-r2   ,thk-r2 ,+0.0000,Lc};
-    Point(004) = {b-r2   ,thk    ,+0.0000,Lc2};
-    
-    Point(011) = {+0.0000,b      ,+0.0000,Lc};
-    Point(012) = {thk-r2 ,b      ,+0.0000,Lc2};
-    Point(013) = {thk-r2 ,b-r2   ,+0.0000,Lc};
-    Point(014) = {thk    ,b-r2   ,+0.0000,Lc2};
-    
-    Point(020) = {thk+r1 ,thk+r1 ,+0.0000,Lc};
-    Point(021) = {thk+r1 ,thk+00 ,+0.0000,Lc2};
-    Point(022) = {thk+00 ,thk+r1 ,+0.0000,Lc2};
-    Point(023) = {thk+00 ,thk+00 ,+0.0000,Lc};
-    
-    Line(1) = {0, 1};
-    Line(2) = {1, 2};
-    Line(3) = {4, 21};
-    Line(4) = {22, 14};
-    Line(5) = {0, 11};
-    Line(6) = {11, 12};
-    Circle(7) = {12, 13, 14};
-    Circle(8) = {22, 20, 21};
-    Circle(9) = {4, 3, 2};
-    Line(10) = {22, 23};
-    Line(11) = {23, 21};
-    Line(12) = {4, 3};
-    Line(13) = {3, 2};
-    Line(14) = {12, 13};
-    Line(15) = {13, 14};
-    Line Loop(16) = {7, -15, -14};
-    Plane Surface(17) = {16};
-    Line Loop(18) = {14, 15, -4, 10, 11, -3, 12, 13, -2, -1, 5, 6};
-    Plane Surface(19) = {18};
-    Line Loop(20) = {8, -11, -10};
-    Plane Surface(21) = {20};
-    Line Loop(22) = {9, -13, -12};
-    Plane Surface(23) = {22};
-   `
-	return geo
-}
-
-// TODO: it is tube ???
+	return BoundaryGeo(a.Boundaries(), prec)
+}
+
+// UnequalAngle is angle with unequal legs. Long leg is along axe Y,
+// short leg is along axe X.
+//
+//	  SCHEMA
+//
+//	--*r2
+//	| *
+//	| *thk
+//	b1*
+//	| *r1
+//	--*****r2
+//	  |-b2-|
+type UnequalAngle struct {
+	Name    string
+	Width1  float64 // b1, long leg
+	Width2  float64 // b2, short leg
+	Thk     float64 // thickness
+	Radius1 float64 // r1, root radius
+	Radius2 float64 // r2, toe radius
+}
 
+func (a UnequalAngle) GetName() string {
+	if a.Name == "" {
+		return fmt.Sprintf("L%.2fx%.2fx%.2f",
+			a.Width1*1e3,
+			a.Width2*1e3,
+			a.Thk*1e3,
+		)
+	}
+	return a.Name
+}
+
+func (a UnequalAngle) Geo(prec float64) string {
+	return BoundaryGeo(a.Boundaries(), prec)
+}
+
+func (a UnequalAngle) Validate() error {
+	switch {
+	case a.Width1 <= 0 || a.Width2 <= 0 || a.Thk <= 0:
+		return fmt.Errorf("sizes of section are not positive")
+	case math.Min(a.Width1, a.Width2) <= a.Thk:
+		return fmt.Errorf("thickness is too big")
+	case a.Radius1 < 0 || a.Radius2 < 0:
+		return fmt.Errorf("negative radius")
+	case a.Thk < a.Radius2 ||
+		math.Min(a.Width1, a.Width2) < a.Thk+a.Radius1+a.Radius2:
+		return fmt.Errorf("radius is too big")
+	}
+	return nil
+}
+
+// UnequalAngles is angles with unequal legs by EN 10056-1 and GOST 8510
+var UnequalAngles = []UnequalAngle{
+	// EN 10056-1
+	{"L40x20x4 EN 10056-1", 0.040, 0.020, 0.004, 0.0040, 0.0020},
+	{"L45x30x4 EN 10056-1", 0.045, 0.030, 0.004, 0.0045, 0.0020},
+	{"L60x30x5 EN 10056-1", 0.060, 0.030, 0.005, 0.0060, 0.0030},
+	{"L60x40x6 EN 10056-1", 0.060, 0.040, 0.006, 0.0060, 0.0030},
+	{"L65x50x5 EN 10056-1", 0.065, 0.050, 0.005, 0.0060, 0.0030},
+	{"L75x50x6 EN 10056-1", 0.075, 0.050, 0.006, 0.0070, 0.0035},
+	{"L80x40x6 EN 10056-1", 0.080, 0.040, 0.006, 0.0070, 0.0035},
+	{"L100x50x6 EN 10056-1", 0.100, 0.050, 0.006, 0.0080, 0.0040},
+	{"L100x65x7 EN 10056-1", 0.100, 0.065, 0.007, 0.0100, 0.0050},
+	{"L100x75x8 EN 10056-1", 0.100, 0.075, 0.008, 0.0100, 0.0050},
+	{"L120x80x8 EN 10056-1", 0.120, 0.080, 0.008, 0.0110, 0.0055},
+	{"L150x90x10 EN 10056-1", 0.150, 0.090, 0.010, 0.0120, 0.0060},
+	{"L200x100x10 EN 10056-1", 0.200, 0.100, 0.010, 0.0150, 0.0075},
+	// GOST 8510
+	{"L45x28x4 GOST 8510", 0.045, 0.028, 0.004, 0.0050, 0.0017},
+	{"L50x32x4 GOST 8510", 0.050, 0.032, 0.004, 0.0055, 0.0018},
+	{"L56x36x5 GOST 8510", 0.056, 0.036, 0.005, 0.0060, 0.0020},
+	{"L63x40x5 GOST 8510", 0.063, 0.040, 0.005, 0.0070, 0.0023},
+	{"L75x50x6 GOST 8510", 0.075, 0.050, 0.006, 0.0080, 0.0027},
+	{"L80x50x6 GOST 8510", 0.080, 0.050, 0.006, 0.0080, 0.0027},
+	{"L90x56x6 GOST 8510", 0.090, 0.056, 0.006, 0.0090, 0.0030},
+	{"L100x63x8 GOST 8510", 0.100, 0.063, 0.008, 0.0100, 0.0033},
+	{"L125x80x10 GOST 8510", 0.125, 0.080, 0.010, 0.0110, 0.0037},
+	{"L160x100x12 GOST 8510", 0.160, 0.100, 0.012, 0.0130, 0.0043},
+	{"L200x125x14 GOST 8510", 0.200, 0.125, 0.014, 0.0140, 0.0047},
+}
+
+// Cylinder is circular hollow section (CHS).
+// Solid round bar for zero thickness.
+//
 // SCHEMA
 //
 //	  **   DIA
@@ -196,39 +253,187 @@ func (c Cylinder) GetName() string {
 }
 
 func (c Cylinder) Geo(prec float64) string {
-	// TODO: use text/template
-	var geo string
-	geo += fmt.Sprintf("dia = %.5f;\n", c.Od)
-	geo += fmt.Sprintf("thk = %.5f;\n", c.Thk)
-	geo += fmt.Sprintf("Lc = %.5f;\n", prec)
-
-	geo += `
-	r = dia/2;
-	Point(001) = {+0.0000,+0.0000,+0.0000,Lc};
-	Point(002) = {+r,+0.0000,+0.0000,Lc};
-	Point(003) = {-r,+0.0000,+0.0000,Lc};
-	Point(004) = {+0.0000,+r,+0.0000,Lc};
-	Point(005) = {+0.0000,-r,+0.0000,Lc};
-	Point(012) = {+r-thk,+0.0000,+0.0000,Lc};
-	Point(013) = {-r+thk,+0.0000,+0.0000,Lc};
-	Point(014) = {+0.0000,+r-thk,+0.0000,Lc};
-	Point(015) = {+0.0000,-r+thk,+0.0000,Lc};
-	Circle(1) = {4, 1, 2};
-	Circle(2) = {2, 1, 5};
-	Circle(3) = {5, 1, 3};
-	Circle(4) = {3, 1, 4};
-	Circle(5) = {14, 1, 12};
-	Circle(6) = {12, 1, 15};
-	Circle(7) = {15, 1, 13};
-	Circle(8) = {13, 1, 14};
-	Line(9) = {12, 2};
-	Line(10) = {13, 3};
-	Line Loop(11) = {2, 3, -10, -7, -6, 9};
-	Plane Surface(12) = {11};
-	Line Loop(13) = {8, 5, 9, -1, -4, -10};
-	Plane Surface(14) = {13};
-`
-	return geo
+	return BoundaryGeo(c.Boundaries(), prec)
+}
+
+// CHSs is hot finished circular hollow sections by EN 10210-2
+var CHSs = []Cylinder{
+	{"CHS 21.3x2.6", 0.0213, 0.0026},
+	{"CHS 26.9x2.6", 0.0269, 0.0026},
+	{"CHS 33.7x3.2", 0.0337, 0.0032},
+	{"CHS 42.4x3.2", 0.0424, 0.0032},
+	{"CHS 48.3x3.2", 0.0483, 0.0032},
+	{"CHS 48.3x4.0", 0.0483, 0.0040},
+	{"CHS 60.3x3.2", 0.0603, 0.0032},
+	{"CHS 60.3x4.0", 0.0603, 0.0040},
+	{"CHS 76.1x3.2", 0.0761, 0.0032},
+	{"CHS 76.1x4.0", 0.0761, 0.0040},
+	{"CHS 88.9x3.2", 0.0889, 0.0032},
+	{"CHS 88.9x4.0", 0.0889, 0.0040},
+	{"CHS 88.9x5.0", 0.0889, 0.0050},
+	{"CHS 114.3x3.6", 0.1143, 0.0036},
+	{"CHS 114.3x5.0", 0.1143, 0.0050},
+	{"CHS 114.3x6.3", 0.1143, 0.0063},
+	{"CHS 139.7x5.0", 0.1397, 0.0050},
+	{"CHS 139.7x6.3", 0.1397, 0.0063},
+	{"CHS 168.3x5.0", 0.1683, 0.0050},
+	{"CHS 168.3x6.3", 0.1683, 0.0063},
+	{"CHS 168.3x8.0", 0.1683, 0.0080},
+	{"CHS 219.1x6.3", 0.2191, 0.0063},
+	{"CHS 219.1x8.0", 0.2191, 0.0080},
+	{"CHS 273.0x8.0", 0.2730, 0.0080},
+	{"CHS 323.9x8.0", 0.3239, 0.0080},
+	{"CHS 323.9x10.0", 0.3239, 0.0100},
+}
+
+// RHS is rectangular hollow section. Square hollow section (SHS) is
+// RHS with equal sizes. Center of section is at origin.
+//
+//	  |---B---|
+//	--*ro***ro*
+//	| *ri   ri*
+//	H *       * Thk
+//	| *ri   ri*
+//	--*ro***ro*
+type RHS struct {
+	Name string
+	H    float64 // height
+	B    float64 // width
+	Thk  float64 // thickness
+	Ro   float64 // outer corner radius
+	Ri   float64 // inner corner radius
+}
+
+// HotRHS return hot finished hollow section with corner radii by
+// EN 10210-2:
+//
+//	ro = 1.5*t
+//	ri = 1.0*t
+func HotRHS(h, b, t float64) RHS {
+	return RHS{
+		Name: fmt.Sprintf("%s %gx%gx%.1f EN 10210-2", rhsName(h, b), 1e3*h, 1e3*b, 1e3*t),
+		H:    h, B: b, Thk: t,
+		Ro: 1.5 * t,
+		Ri: 1.0 * t,
+	}
+}
+
+// ColdRHS return cold formed hollow section with corner radii by
+// EN 10219-2:
+//
+//	t <= 6 mm       : ro = 2.0*t, ri = 1.0*t
+//	6 < t <= 10 mm  : ro = 2.5*t, ri = 1.5*t
+//	t > 10 mm       : ro = 3.0*t, ri = 2.0*t
+func ColdRHS(h, b, t float64) RHS {
+	ro, ri := 2.0*t, 1.0*t
+	switch {
+	case 0.010 < t:
+		ro, ri = 3.0*t, 2.0*t
+	case 0.006 < t:
+		ro, ri = 2.5*t, 1.5*t
+	}
+	return RHS{
+		Name: fmt.Sprintf("%s %gx%gx%.1f EN 10219-2", rhsName(h, b), 1e3*h, 1e3*b, 1e3*t),
+		H:    h, B: b, Thk: t,
+		Ro: ro,
+		Ri: ri,
+	}
+}
+
+func rhsName(h, b float64) string {
+	if h == b {
+		return "SHS"
+	}
+	return "RHS"
+}
+
+func (r RHS) GetName() string {
+	if r.Name == "" {
+		return fmt.Sprintf("%s H%.2f x B%.2f x Thk%.2f",
+			rhsName(r.H, r.B),
+			1e3*r.H,
+			1e3*r.B,
+			1e3*r.Thk,
+		)
+	}
+	return r.Name
+}
+
+func (r RHS) Geo(prec float64) string {
+	return BoundaryGeo(r.Boundaries(), prec)
+}
+
+// Validate return error for not valid sizes of section
+func (r RHS) Validate() error {
+	switch {
+	case r.H <= 0 || r.B <= 0 || r.Thk <= 0:
+		return fmt.Errorf("sizes of section are not positive")
+	case math.Min(r.H, r.B) <= 2*r.Thk:
+		return fmt.Errorf("thickness is too big")
+	case r.Ro < 0 || r.Ri < 0:
+		return fmt.Errorf("negative corner radius")
+	case math.Min(r.H, r.B) < 2*r.Ro || math.Min(r.H, r.B)-2*r.Thk < 2*r.Ri:
+		return fmt.Errorf("corner radius is too big")
+	}
+	return nil
+}
+
+// RHSs is rectangular hollow sections
+var RHSs = []RHS{
+	HotRHS(0.050, 0.030, 0.0032),
+	HotRHS(0.060, 0.040, 0.0040),
+	HotRHS(0.080, 0.040, 0.0040),
+	HotRHS(0.100, 0.050, 0.0050),
+	HotRHS(0.100, 0.060, 0.0050),
+	HotRHS(0.120, 0.060, 0.0063),
+	HotRHS(0.120, 0.080, 0.0063),
+	HotRHS(0.150, 0.100, 0.0063),
+	HotRHS(0.150, 0.100, 0.0080),
+	HotRHS(0.160, 0.080, 0.0063),
+	HotRHS(0.200, 0.100, 0.0080),
+	HotRHS(0.200, 0.100, 0.0100),
+	HotRHS(0.250, 0.150, 0.0100),
+	HotRHS(0.300, 0.200, 0.0100),
+
+	ColdRHS(0.060, 0.040, 0.0030),
+	ColdRHS(0.080, 0.040, 0.0030),
+	ColdRHS(0.100, 0.050, 0.0040),
+	ColdRHS(0.120, 0.060, 0.0040),
+	ColdRHS(0.150, 0.100, 0.0050),
+	ColdRHS(0.200, 0.100, 0.0060),
+}
+
+// SHSs is square hollow sections
+var SHSs = []RHS{
+	HotRHS(0.040, 0.040, 0.0032),
+	HotRHS(0.040, 0.040, 0.0040),
+	HotRHS(0.050, 0.050, 0.0040),
+	HotRHS(0.050, 0.050, 0.0050),
+	HotRHS(0.060, 0.060, 0.0040),
+	HotRHS(0.060, 0.060, 0.0050),
+	HotRHS(0.080, 0.080, 0.0050),
+	HotRHS(0.080, 0.080, 0.0063),
+	HotRHS(0.100, 0.100, 0.0050),
+	HotRHS(0.100, 0.100, 0.0063),
+	HotRHS(0.100, 0.100, 0.0080),
+	HotRHS(0.120, 0.120, 0.0063),
+	HotRHS(0.120, 0.120, 0.0080),
+	HotRHS(0.140, 0.140, 0.0080),
+	HotRHS(0.140, 0.140, 0.0100),
+	HotRHS(0.150, 0.150, 0.0080),
+	HotRHS(0.150, 0.150, 0.0100),
+	HotRHS(0.200, 0.200, 0.0080),
+	HotRHS(0.200, 0.200, 0.0100),
+	HotRHS(0.200, 0.200, 0.0125),
+	HotRHS(0.250, 0.250, 0.0100),
+	HotRHS(0.250, 0.250, 0.0125),
+
+	ColdRHS(0.040, 0.040, 0.0020),
+	ColdRHS(0.050, 0.050, 0.0030),
+	ColdRHS(0.060, 0.060, 0.0030),
+	ColdRHS(0.080, 0.080, 0.0040),
+	ColdRHS(0.100, 0.100, 0.0040),
+	ColdRHS(0.120, 0.120, 0.0050),
 }
 
 ////////////////////////////////////
@@ -341,89 +546,7 @@ var Isections = []Isection{
 }
 
 func (is Isection) Geo(prec float64) string {
-	tmplString := `
-h        = {{ .H }}  ;
-b        = {{ .B }}  ;
-s        = {{ .Tw }} ;
-t        = {{ .Tf }} ;
-R        = {{ .Radius }};
-prec     = {{ .Prec }} ;
-prec2    = {{ .Tw }} ;
-Point(0) ={0,0,0,prec2};
-Point(1) ={b,0,0,prec2};
-Point(2) ={b,t,0,prec2};
-Point(3) ={b/2+s/2+R,t,0,prec};
-Point(4) ={b/2+s/2+R,t+R,0,prec};
-Point(5) ={b/2+s/2,t+R,0,prec};
-Point(6) ={b/2+s/2,h-t-R,0,prec};
-Point(7) ={b/2+s/2+R,h-t-R,0,prec};
-Point(8) ={b/2+s/2+R,h-t,0,prec};
-Point(9) ={b,h-t,0,prec2};
-Point(10)={b,h,0,prec2};
-Point(11)={0,h,0,prec2};
-Point(12)={0,h-t,0,prec2};
-Point(13)={b/2-s/2-R,h-t,0,prec};
-Point(14)={b/2-s/2,h-t-R,0,prec};
-Point(15)={b/2-s/2-R,h-t-R,0,prec};
-Point(16)={b/2-s/2,t+R,0,prec};
-Point(17)={b/2-s/2-R,t+R,0,0,prec};
-Point(18)={b/2-s/2-R,t,0,prec};
-Point(19)={0,t,0,prec2};
-Point(20)={b/2+s/2,h/2,0,prec2};
-Point(21)={b/2-s/2,h/2,0,prec2};
-Point(22)={b/2-s/2,t,0,prec2};
-Point(23)={b/2+s/2,t,0,prec2};
-Point(24)={b/2-s/2,h-t,0,prec2};
-Point(25)={b/2+s/2,h-t,0,prec2};
-Line(1) = {0, 1};
-Line(2) = {1, 2};
-Line(3) = {2, 3};
-Line(4) = {0, 19};
-Line(5) = {19, 18};
-Line(6) = {8, 9};
-Line(7) = {9, 10};
-Line(8) = {10, 11};
-Line(9) = {11, 12};
-Line(10) = {12, 13};
-Line(11) = {6, 20};
-Line(12) = {20, 5};
-Line(13) = {16, 21};
-Line(14) = {21, 14};
-Circle(15) = {6, 7, 8};
-Circle(16) = {14, 15, 13};
-Circle(17) = {18, 17, 16};
-Circle(18) = {3, 4, 5};
-Line(19) = {18, 22};
-Line(20) = {22, 16};
-Line(21) = {3, 23};
-Line(22) = {23, 5};
-Line(23) = {13, 24};
-Line(24) = {24, 14};
-Line(25) = {6, 25};
-Line(26) = {25, 8};
-Line Loop(27) = {1, 2, 3, 21, 22, -12, -11, 25, 26, 6, 7, 8, 9, 10, 23, 24, -14, -13, -20, -19, -5, -4};
-Plane Surface(28) = {27};
-Line Loop(29) = {16, 23, 24};
-Plane Surface(30) = {29};
-Line Loop(31) = {25, 26, -15};
-Plane Surface(32) = {31};
-Line Loop(33) = {20, -17, 19};
-Plane Surface(34) = {33};
-Line Loop(35) = {18, -22, -21};
-Plane Surface(36) = {35};
-`
-	var (
-		value = struct {
-			Isection
-			Prec float64
-		}{Isection: is, Prec: prec}
-		tmpl = template.Must(template.New("geo").Parse(tmplString))
-		buf  bytes.Buffer
-	)
-	if err := tmpl.Execute(&buf, value); err != nil {
-		panic(err)
-	}
-	return buf.String()
+	return BoundaryGeo(is.Boundaries(), prec)
 }
 
 // Rectangle
@@ -451,25 +574,7 @@ func (r Rectangle) GetName() string {
 }
 
 func (r Rectangle) Geo(prec float64) string {
-	// TODO: use text/template
-	var geo string
-	geo += fmt.Sprintf("h   = %.5f;\n", r.H)
-	geo += fmt.Sprintf("thk = %.5f;\n", r.Thk)
-	geo += fmt.Sprintf("Lc = %.5f;\n", prec)
-
-	geo += `
-	Point(000) = {-thk/2.0,+0.0000,+0.0000,Lc};
-	Point(001) = {+thk/2.0,+0.0000,+0.0000,Lc};
-	Point(002) = {-thk/2.0,h      ,+0.0000,Lc};
-	Point(003) = {+thk/2.0,h      ,+0.0000,Lc};
-	Line(1) = {1, 3};
-	Line(2) = {0, 2};
-	Line(3) = {0, 1};
-	Line(4) = {2, 3};
-	Line Loop(5) = {1, -4, -2, 3};
-	Plane Surface(6) = {5};
-`
-	return geo
+	return BoundaryGeo(r.Boundaries(), prec)
 }
 
 var Rectangles = []Rectangle{
@@ -514,28 +619,59 @@ func (pg PlateGroup) GetName() string {
 }
 
 func (pg PlateGroup) Geo(prec float64) string {
-	var geo string
-	geo += fmt.Sprintf("Lc = %.5f;\n", prec)
-	for i, p := range pg.Plates {
-		geo += fmt.Sprintf("Xc = %.5f;\n", p.Xc)
-		geo += fmt.Sprintf("Yc = %.5f;\n", p.Yc)
-		geo += fmt.Sprintf("X  = %.5f;\n", p.X)
-		geo += fmt.Sprintf("Y  = %.5f;\n", p.Y)
-		geo += fmt.Sprintf("ID = %d;\n", (i+1)*10000) // maximal 10000 plates
-		geo += `
-	Point(ID + 000) = {Xc - X/2.0 , Yc - Y/2.0,+0.0000,Lc};
-	Point(ID + 001) = {Xc + X/2.0 , Yc - Y/2.0,+0.0000,Lc};
-	Point(ID + 002) = {Xc - X/2.0 , Yc + Y/2.0,+0.0000,Lc};
-	Point(ID + 003) = {Xc + X/2.0 , Yc + Y/2.0,+0.0000,Lc};
-	Line(ID + 1) = { ID + 1, ID + 3};
-	Line(ID + 2) = { ID + 0, ID + 2};
-	Line(ID + 3) = { ID + 0, ID + 1};
-	Line(ID + 4) = { ID + 2, ID + 3};
-	Line Loop(ID + 5) = {ID + 1, -(ID + 4),-( ID + 2), ID + 3};
-	Plane Surface(ID + 6) = {ID + 5};
-`
-	}
-	return geo
+	return BoundaryGeo(pg.Boundaries(), prec)
+}
+
+// Polygon is section with user outline. Outer contour is
+// counterclockwise, holes are clockwise.
+//
+//	 r     r
+//	*-------*
+//	|  *-*  |
+//	|  | |  |
+//	|  *-*  |
+//	*-------*
+//	 r     r
+type Polygon struct {
+	Name  string
+	Outer []Vertex   // outer contour
+	Holes [][]Vertex // contours of holes
+}
+
+// Vertex is corner of polygon
+type Vertex struct {
+	X, Y   float64
+	Radius float64 // fillet radius, zero for sharp corner
+}
+
+func (p Polygon) GetName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "%s\n", "Polygon")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Contour\t№\tX\tY\tRadius\n")
+	table := func(name string, vs []Vertex) {
+		for i, v := range vs {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n",
+				name, i,
+				efmt.Sprint(v.X), efmt.Sprint(v.Y), efmt.Sprint(v.Radius),
+			)
+		}
+	}
+	table("Outer", p.Outer)
+	for i := range p.Holes {
+		table(fmt.Sprintf("Hole %d", i), p.Holes[i])
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+func (p Polygon) Geo(prec float64) string {
+	return BoundaryGeo(p.Boundaries(), prec)
 }
 
 // Tsection
@@ -571,35 +707,7 @@ func (t Tsection) GetName() string {
 }
 
 func (t Tsection) Geo(prec float64) string {
-	// TODO: use text/template
-	var geo string
-	geo += fmt.Sprintf("H    = %.5f;\n", t.H)
-	geo += fmt.Sprintf("Thk  = %.5f;\n", t.Thk)
-	geo += fmt.Sprintf("L    = %.5f;\n", t.L)
-	geo += fmt.Sprintf("Thk2 = %.5f;\n", t.Thk2)
-	geo += fmt.Sprintf("Lc   = %.5f;\n", prec)
-
-	geo += `
-	Point(000) = {-Thk/2.0,+0.0000,+0.0000,Lc};
-	Point(001) = {-Thk/2.0,H      ,+0.0000,Lc};
-	Point(002) = {+Thk/2.0,H      ,+0.0000,Lc};
-	Point(003) = {+Thk/2.0,+0.0000,+0.0000,Lc};
-	Point(004) = {+L/2.0  ,+0.0000,+0.0000,Lc};
-	Point(005) = {+L/2.0  ,-Thk2/2.0,+0.0000,Lc};
-	Point(006) = {-L/2.0  ,-Thk2/2.0,+0.0000,Lc};
-	Point(007) = {-L/2.0  ,+0.0000,+0.0000,Lc};
-	Line(1) = {0, 1};
-	Line(2) = {1, 2};
-	Line(3) = {2, 3};
-	Line(4) = {3, 4};
-	Line(5) = {4, 5};
-	Line(6) = {5, 6};
-	Line(7) = {6, 7};
-	Line(8) = {7, 0};
-	Line Loop(10) = {1,2,3,4,5,6,7,8};
-	Plane Surface(20) = {10};
-`
-	return geo
+	return BoundaryGeo(t.Boundaries(), prec)
 }
 
 // UPN
@@ -624,6 +732,7 @@ type UPN struct {
 	Tw      float64 //tw
 	Radius1 float64 //r1
 	Radius2 float64 //r2
+	Gost    bool    // thickness tf at (b-tw)/2 from toe of flange as in GOST 8240
 
 	// TODO: angle of flange
 }
@@ -655,129 +764,38 @@ func (u UPN) String() string {
 }
 
 var UPNs = []UPN{ // TODO: check
-	{"UPN120 DIN 1025-5-1994", 0.1200, 0.0550, 0.0090, 0.0070, 0.0090, 0.0045},
-	{"UPN140 DIN 1025-5-1994", 0.1400, 0.0600, 0.0100, 0.0070, 0.0100, 0.0050},
-	{"UPN160 DIN 1025-5-1994", 0.1600, 0.0650, 0.0105, 0.0075, 0.0105, 0.0055},
-	{"UPN180 DIN 1025-5-1994", 0.1800, 0.0700, 0.0110, 0.0080, 0.0110, 0.0055},
-	{"UPN200 DIN 1025-5-1994", 0.2000, 0.0750, 0.0115, 0.0085, 0.0115, 0.0060},
-	{"UPN240 DIN 1025-5-1994", 0.2400, 0.0850, 0.0130, 0.0095, 0.0130, 0.0065},
-	{"UPN300 DIN 1025-5-1994", 0.3000, 0.1000, 0.0160, 0.0100, 0.0160, 0.0080},
-	{"UPN400 DIN 1025-5-1994", 0.4000, 0.1100, 0.0180, 0.0140, 0.0180, 0.0090},
-
-	{"Швеллер 12У ГОСТ 8240", 0.120, 0.052, 0.0078, 0.0048, 0.0075, 0.0030},
-	{"Швеллер 16У ГОСТ 8240", 0.160, 0.064, 0.0084, 0.0050, 0.0085, 0.0035},
-	{"Швеллер 20У ГОСТ 8240", 0.200, 0.076, 0.0090, 0.0052, 0.0095, 0.0040},
-	{"Швеллер 24У ГОСТ 8240", 0.240, 0.090, 0.0100, 0.0056, 0.0105, 0.0040},
-	{"Швеллер 30У ГОСТ 8240", 0.300, 0.100, 0.0110, 0.0065, 0.0120, 0.0050},
-	{"Швеллер 36У ГОСТ 8240", 0.360, 0.110, 0.0126, 0.0075, 0.0140, 0.0060},
-	{"Швеллер 40У ГОСТ 8240", 0.400, 0.115, 0.0135, 0.0080, 0.0150, 0.0060},
-}
+	{"UPN120 DIN 1025-5-1994", 0.1200, 0.0550, 0.0090, 0.0070, 0.0090, 0.0045, false},
+	{"UPN140 DIN 1025-5-1994", 0.1400, 0.0600, 0.0100, 0.0070, 0.0100, 0.0050, false},
+	{"UPN160 DIN 1025-5-1994", 0.1600, 0.0650, 0.0105, 0.0075, 0.0105, 0.0055, false},
+	{"UPN180 DIN 1025-5-1994", 0.1800, 0.0700, 0.0110, 0.0080, 0.0110, 0.0055, false},
+	{"UPN200 DIN 1025-5-1994", 0.2000, 0.0750, 0.0115, 0.0085, 0.0115, 0.0060, false},
+	{"UPN240 DIN 1025-5-1994", 0.2400, 0.0850, 0.0130, 0.0095, 0.0130, 0.0065, false},
+	{"UPN300 DIN 1025-5-1994", 0.3000, 0.1000, 0.0160, 0.0100, 0.0160, 0.0080, false},
+	{"UPN400 DIN 1025-5-1994", 0.4000, 0.1100, 0.0180, 0.0140, 0.0180, 0.0090, false},
+
+	{"Швеллер 12У ГОСТ 8240", 0.120, 0.052, 0.0078, 0.0048, 0.0075, 0.0030, true},
+	{"Швеллер 16У ГОСТ 8240", 0.160, 0.064, 0.0084, 0.0050, 0.0085, 0.0035, true},
+	{"Швеллер 20У ГОСТ 8240", 0.200, 0.076, 0.0090, 0.0052, 0.0095, 0.0040, true},
+	{"Швеллер 24У ГОСТ 8240", 0.240, 0.090, 0.0100, 0.0056, 0.0105, 0.0040, true},
+	{"Швеллер 30У ГОСТ 8240", 0.300, 0.100, 0.0110, 0.0065, 0.0120, 0.0050, true},
+	{"Швеллер 36У ГОСТ 8240", 0.360, 0.110, 0.0126, 0.0075, 0.0140, 0.0060, true},
+	{"Швеллер 40У ГОСТ 8240", 0.400, 0.115, 0.0135, 0.0080, 0.0150, 0.0060, true},
+}
+
+// DoubleUPNs is channels UPNs with webs back to back without gap
+var DoubleUPNs []Compound
 
 func init() {
 	// initialize double channels
 	for _, upn := range UPNs {
-		var is Isection
-		is.Name = upn.Name + ",Double"
-		is.H = upn.H
-		is.B = upn.B * 2.0
-		is.Tf = upn.Tf
-		is.Tw = upn.Tw * 2.0
-		is.Radius = upn.Radius1
-		Isections = append(Isections, is)
+		c := DoubleChannels(upn, 0, false)
+		c.Name = upn.Name + ",Double"
+		DoubleUPNs = append(DoubleUPNs, c)
 	}
 }
 
 func (u UPN) Geo(prec float64) string {
-	// TODO: use text/template
-	// TODO: simplification of geo file
-	var geo string
-	geo += fmt.Sprintf("h  = %.5f;\n", u.H)
-	geo += fmt.Sprintf("b  = %.5f;\n", u.B)
-	geo += fmt.Sprintf("tf = %.5f;\n", u.Tf)
-	geo += fmt.Sprintf("tw = %.5f;\n", u.Tw)
-	geo += fmt.Sprintf("r1 = %.5f;\n", u.Radius1)
-	geo += fmt.Sprintf("r2 = %.5f;\n", u.Radius2)
-	geo += fmt.Sprintf("Lc = %.5f;\n", u.Tw/2)
-	geo += fmt.Sprintf("Lc2 = %.5f;\n", prec)
-	geo += `
-	u = b/2;
-	degree = 0.08;
-	If(h > 0.300)
-	u = (b-tw)/2;
-	degree = 0.05;
-	EndIf
-	
-	Point(000) = {+0.0000,+0.0000,+0.0000,Lc};
-	Point(002) = {b      ,+0.0000,+0.0000,Lc};
-	Point(004) = {b-u    ,0+tf   ,+0.0000,Lc};
-	Point(005) = {+0.0000,h/2    ,+0.0000,Lc};
-	Point(006) = {tw     ,h/2    ,+0.0000,Lc};
-	
-	
-	Point(100) = {+0.0000,h      ,+0.0000,Lc};
-	Point(102) = {b      ,h      ,+0.0000,Lc};
-	Point(104) = {b-u    ,h-tf   ,+0.0000,Lc};
-	
-	betta      = (-Atan(degree) + 3.1415926/2)/2;
-	Yc         = tf - u*degree;
-	Point(007) = {b      ,Yc     ,+0.0000,Lc};
-	Point(008) = {b      ,Yc-r2*Tan(betta),+0.0000,Lc2};
-	Point(009) = {b-r2   ,Yc-r2*Tan(betta),+0.0000,Lc2};
-	Point(010) = {b-r2+r2*Cos(2*betta),Yc-r2*Tan(betta)+r2*Sin(2*betta),+0.0000,Lc2};
-	Point(107) = {b      ,h-Yc   ,+0.0000,Lc};
-	Point(108) = {b      ,h-(Yc-r2*Tan(betta)),+0.0000,Lc2};
-	Point(109) = {b-r2   ,h-(Yc-r2*Tan(betta)),+0.0000,Lc2};
-	Point(110) = {b-r2+r2*Cos(2*betta),h-(Yc-r2*Tan(betta)+r2*Sin(2*betta)),+0.0000,Lc2};
-	
-	Yc         = tf + (b-tw-u)*degree;
-	Point(027) = {tw     ,Yc              ,+0.0000,Lc2};
-	Point(028) = {tw     ,Yc+r1*Tan(betta),+0.0000,Lc2};
-	Point(029) = {tw+r1  ,Yc+r1*Tan(betta),+0.0000,Lc};
-	Point(030) = {tw+r1-r1*Cos(2*betta),Yc+r1*Tan(betta)-r1*Sin(2*betta),+0.0000,Lc2};
-	Point(127) = {tw     ,h-Yc                ,+0.0000,Lc2};
-	
-	Point(128) = {tw     ,h-(Yc+r1*Tan(betta)),+0.0000,Lc2};
-	Point(129) = {tw+r1  ,h-(Yc+r1*Tan(betta)),+0.0000,Lc};
-	Point(130) = {tw+r1-r1*Cos(2*betta),h-(Yc+r1*Tan(betta)-r1*Sin(2*betta)),+0.0000,Lc2};
-	
-	Line(1) = {102, 100};
-	Line(2) = {100, 5};
-	Line(3) = {5, 0};
-	Line(4) = {0, 2};
-	Line(5) = {2, 8};
-	Line(6) = {10, 4};
-	Line(7) = {4, 30};
-	Line(8) = {28, 6};
-	Line(9) = {6, 128};
-	Line(10) = {130, 104};
-	Line(11) = {104, 110};
-	Line(12) = {108, 102};
-	Line(13) = {109, 110};
-	Line(14) = {109, 108};
-	Line(15) = {127, 128};
-	Line(16) = {127, 130};
-	Line(17) = {28, 27};
-	Line(18) = {27, 30};
-	Line(19) = {10, 9};
-	Line(20) = {9, 8};
-	Circle(21) = {10, 9, 8};
-	Circle(24) = {110, 109, 108};
-	
-	Line Loop(25) = {14, -24, -13};
-	Plane Surface(26) = {25};
-	Line Loop(27) = {19, 20, -21};
-	Plane Surface(28) = {27};
-	Line Loop(29) = {11, -13, 14, 12, 1, 2, 3, 4, 5, -20, -19, 6, 7, -18, -17, 8, 9, -15, 16, 10};
-	Plane Surface(30) = {29};
-	
-	Circle(31) = {128, 129, 130};
-	Line Loop(32) = {31, -16, 15};
-	Plane Surface(33) = {32};
-	Circle(34) = {28, 29, 30};
-	Line Loop(35) = {18, -34, 17};
-	Plane Surface(36) = {35};
-`
-	return geo
+	return BoundaryGeo(u.Boundaries(), prec)
 }
 
 // WPG - welded I-section
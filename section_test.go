@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/Konstantin8105/compare"
+	"github.com/Konstantin8105/msh"
 	"github.com/Konstantin8105/section"
 )
 
@@ -19,6 +20,22 @@ func td(filename string) string {
 	return filepath.Join("testdata", filename)
 }
 
+// tsection is flange 200x10 on top of web 190x10
+var tsection = section.PlateGroup{Plates: []section.Plate{
+	{Xc: 0, Yc: 0.195, X: 0.200, Y: 0.010},
+	{Xc: 0, Yc: 0.095, X: 0.010, Y: 0.190},
+}}
+
+// properties of tsection: area, center, plastic neutral axe with equal
+// areas 0.2*(0.2-yp) = a/2 and plastic modulus
+var (
+	tsectionA   = 0.200*0.010 + 0.010*0.190
+	tsectionY   = (0.200*0.010*0.195 + 0.010*0.190*0.095) / tsectionA
+	tsectionYp  = 0.2 - tsectionA/2/0.2
+	tsectionWpl = 0.2*math.Pow(0.2-tsectionYp, 2)/2 + 0.2*math.Pow(tsectionYp-0.19, 2)/2 +
+		0.010*0.190*(tsectionYp-0.095)
+)
+
 func ExampleGet() {
 	g, err := section.Get("20B1-ASCM")
 	if err != nil {
@@ -212,75 +229,2224 @@ func TestUB(t *testing.T) {
 			compare(20, pr.AtCenterPoint.WxPlastic*1e6)
 			compare(21, pr.AtCenterPoint.WyPlastic*1e6)
 
+			compare(25, pr.Torsion.It*1e8)
 			compare(26, pr.A*1e4)
-			// TODO: check torsion
 		})
 	}
 }
 
-func Test(t *testing.T) {
+func TestUPN(t *testing.T) {
+	// area of section by catalogues in cm2
+	areas := map[string]float64{
+		"UPN120 DIN 1025-5-1994": 17.0,
+		"UPN140 DIN 1025-5-1994": 20.4,
+		"UPN160 DIN 1025-5-1994": 24.0,
+		"UPN180 DIN 1025-5-1994": 28.0,
+		"UPN200 DIN 1025-5-1994": 32.2,
+		"UPN240 DIN 1025-5-1994": 42.3,
+		"UPN300 DIN 1025-5-1994": 58.8,
+		"UPN400 DIN 1025-5-1994": 91.5,
+		"Швеллер 12У ГОСТ 8240":  13.3,
+		"Швеллер 16У ГОСТ 8240":  18.1,
+		"Швеллер 20У ГОСТ 8240":  23.4,
+		"Швеллер 24У ГОСТ 8240":  30.6,
+		"Швеллер 30У ГОСТ 8240":  40.5,
+		"Швеллер 36У ГОСТ 8240":  53.4,
+		"Швеллер 40У ГОСТ 8240":  61.5,
+	}
+	if len(areas) != len(section.UPNs) {
+		t.Fatalf("not all channels in catalogue: %d != %d", len(areas), len(section.UPNs))
+	}
+	for _, u := range section.UPNs {
+		t.Run(u.Name, func(t *testing.T) {
+			pr, err := section.CalculateBending(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect, ok := areas[u.Name]
+			if !ok {
+				t.Fatalf("no catalogue area")
+			}
+			if eps := 0.5 / 100.0; eps < math.Abs(pr.A*1e4-expect)/expect {
+				t.Errorf("area %8.3f != %8.3f", pr.A*1e4, expect)
+			}
+		})
+	}
+}
+
+func TestShearCenter(t *testing.T) {
+	check := func(t *testing.T, name string, act, expect, eps float64) {
+		t.Helper()
+		if diff := math.Abs(act - expect); eps < diff {
+			t.Errorf("%s: %e != %e. Diff = %e", name, act, expect, diff)
+		}
+	}
 	t.Run("channel", func(t *testing.T) {
-		name := "Швеллер 20У ГОСТ 8240"
-		c20, err := section.Get(name)
+		// catalogue values of ArcelorMittal:
+		//	ys - distance from back of web to centroid, cm
+		//	ym - distance from centroid to shear center, cm
+		//	iw - warping constant, cm6
+		tcs := []struct {
+			name       string
+			ys, ym, iw float64
+		}{
+			{"UPN180 DIN 1025-5-1994", 1.92, 3.75, 5.57e3},
+			{"UPN200 DIN 1025-5-1994", 2.01, 3.94, 9.07e3},
+			{"UPN240 DIN 1025-5-1994", 2.23, 4.39, 22.1e3},
+			{"UPN300 DIN 1025-5-1994", 2.70, 5.41, 69.1e3},
+			{"UPN400 DIN 1025-5-1994", 2.65, 5.11, 221e3},
+		}
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var upn section.UPN
+				for _, u := range section.UPNs {
+					if u.GetName() == tc.name {
+						upn = u
+					}
+				}
+				if upn.Name == "" {
+					t.Fatalf("not found %s", tc.name)
+				}
+				pr, err := section.Calculate(upn)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ys := tc.ys * 1e-2
+				ym := tc.ym * 1e-2
+				iw := tc.iw * 1e-12
+				check(t, "ys", pr.X, ys, 0.02*ys)
+				check(t, "ym", pr.X-pr.AtBasePoint.Xs, ym, 0.02*ym)
+				check(t, "Ys", pr.AtBasePoint.Ys, upn.H/2.0, 1e-3*upn.H)
+				check(t, "Xs center", pr.AtCenterPoint.Xs, pr.AtBasePoint.Xs-pr.X, 1e-9)
+				check(t, "Ys center", pr.AtCenterPoint.Ys, pr.AtBasePoint.Ys-pr.Y, 1e-9)
+				check(t, "Iw", pr.Torsion.Iw, iw, 0.02*iw)
+			})
+		}
+	})
+	t.Run("angle", func(t *testing.T) {
+		// thin angle with sharp corners, shear center is at intersection
+		// of legs and warping constant is (Kollbrunner, Basler):
+		//	Iw = t^3 * b^3 / 18, b - width of leg at middle line
+		const b = 0.100
+		for _, thk := range []float64{0.002, 0.003} {
+			pg := section.PlateGroup{
+				Name: fmt.Sprintf("L%.0fx%.0f", b*1e3, thk*1e3),
+				Plates: []section.Plate{
+					{Xc: b / 2.0, Yc: thk / 2.0, X: b, Y: thk},
+					{Xc: thk / 2.0, Yc: (b + thk) / 2.0, X: thk, Y: b - thk},
+				},
+			}
+			t.Run(pg.GetName(), func(t *testing.T) {
+				pr, err := section.Calculate(pg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				check(t, "Xs", pr.AtBasePoint.Xs, thk/2.0, 0.02*thk)
+				check(t, "Ys", pr.AtBasePoint.Ys, thk/2.0, 0.02*thk)
+				iw := math.Pow(thk, 3) * math.Pow(b-thk/2.0, 3) / 18.0
+				check(t, "Iw", pr.Torsion.Iw, iw, 0.01*iw)
+			})
+		}
+		for _, a := range section.Angles {
+			t.Run(a.GetName(), func(t *testing.T) {
+				pr, err := section.Calculate(a)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// shear center is on axe of symmetry
+				check(t, "Xs=Ys", pr.AtBasePoint.Xs, pr.AtBasePoint.Ys, 1e-3*a.Thk)
+			})
+		}
+	})
+}
+
+// Example:
+// http://www.learneasy.info/MDME/MEMmods/MEM09155A-CAE/050-Shear-in-Bending/shear-in-bending.html
+func TestShearStress(t *testing.T) {
+	// I-section
+	//	height = 150 mm
+	//	width  = 100 mm
+	//	tw     = 10 mm
+	//	tf     = 10 mm
+	//	J = 1.16e7 mm4
+	pg := section.PlateGroup{
+		Name: "I-section 150x100x10x10",
+		Plates: []section.Plate{
+			{Xc: 0.0, Yc: 0.005, X: 0.100, Y: 0.010},
+			{Xc: 0.0, Yc: 0.075, X: 0.010, Y: 0.130},
+			{Xc: 0.0, Yc: 0.145, X: 0.100, Y: 0.010},
+		},
+	}
+	mesh, err := section.GenerateMsh(pg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, center := section.Area(*mesh)
+	section.MoveXOY(mesh, -center.Coord[0], -center.Coord[1])
+
+	const V = 25e3 // N
+	tcs := []struct {
+		name   string
+		y      float64
+		q, tau float64
+	}{
+		// section A-A on 30 mm from neutral axe
+		{name: "A-A", y: 0.030, q: 86625e-9, tau: 18.6e6},
+		// section B-B on the border between web and flange
+		{name: "B-B web", y: 0.065 - 1e-6, q: 70000e-9, tau: 15.0e6},
+		{name: "B-B flange", y: 0.065 + 1e-6, q: 70000e-9, tau: 1.5e6},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := section.ShearStress(*mesh, V, tc.y)
+			if eps := 0.01; eps < math.Abs((sc.Q-tc.q)/tc.q) {
+				t.Errorf("Q: %e != %e", sc.Q, tc.q)
+			}
+			if eps := 0.01; eps < math.Abs((sc.Tau-tc.tau)/tc.tau) {
+				t.Errorf("tau: %e != %e", sc.Tau, tc.tau)
+			}
+		})
+	}
+	t.Run("rectangle", func(t *testing.T) {
+		r := section.Rectangles[3]
+		pr, err := section.Calculate(r)
 		if err != nil {
 			t.Fatal(err)
 		}
-		pr, err := section.Calculate(c20)
+		// shear area of rectangle is 5/6*A
+		for _, av := range []float64{pr.OnSectionAxe.Avx, pr.OnSectionAxe.Avy} {
+			if eps := 0.01; eps < math.Abs(av/pr.A-5.0/6.0) {
+				t.Errorf("shear area: %e != %e", av, 5.0/6.0*pr.A)
+			}
+		}
+	})
+}
+
+func TestStresses(t *testing.T) {
+	r := section.Rectangles[3]
+	pr, mesh, err := section.CalculateWithMesh(r, section.BasePoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(t *testing.T, name string, act, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs((act-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, act, expect)
+		}
+	}
+	t.Run("bending", func(t *testing.T) {
+		f := section.Forces{N: 1e3, Mx: 1e3}
+		s, err := section.Stresses(*mesh, f)
 		if err != nil {
 			t.Fatal(err)
 		}
-		s := printJson(pr)
-		compare.Test(t, td(".test"), []byte(s))
+		check(t, "SigmaMax", s.SigmaMax.Value, f.N/pr.A+f.Mx/pr.AtCenterPoint.Wx, 1e-3)
+		check(t, "SigmaMin", s.SigmaMin.Value, f.N/pr.A-f.Mx/pr.AtCenterPoint.Wx, 1e-3)
+		check(t, "Y of SigmaMax", s.SigmaMax.Y, r.H, 1e-6)
+	})
+	t.Run("torsion", func(t *testing.T) {
+		f := section.Forces{T: 1e3}
+		s, err := section.Stresses(*mesh, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "TauMax", s.TauMax.Value, f.T*pr.Torsion.Tau, 0.10)
+		check(t, "VonMises", s.VonMisesMax.Value, math.Sqrt(3)*s.TauMax.Value, 1e-6)
+	})
+	t.Run("shear", func(t *testing.T) {
+		f := section.Forces{Vy: 1e3}
+		s, err := section.Stresses(*mesh, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// maximal shear stress in rectangle is 3/2*V/A
+		check(t, "TauMax", s.TauMax.Value, 1.5*f.Vy/pr.A, 0.05)
+	})
+	t.Run("angle", func(t *testing.T) {
+		a := section.Angles[5]
+		pr, mesh, err := section.CalculateWithMesh(a, section.CenterPoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := section.Forces{N: 1e3, Mx: 2e3, My: -1e3}
+		s, err := section.Stresses(*mesh, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// resultants of linear stresses on triangles
+		var n, mx, my float64
+		for _, e := range mesh.Elements {
+			if e.EType != msh.Triangle {
+				continue
+			}
+			var sg, x, y [3]float64
+			for i, id := range e.NodeId {
+				k := mesh.GetNode(id)
+				sg[i], x[i], y[i] = s.Sigma[k], mesh.Nodes[k].Coord[0], mesh.Nodes[k].Coord[1]
+			}
+			area := ((x[1]-x[0])*(y[2]-y[0]) - (x[2]-x[0])*(y[1]-y[0])) / 2
+			integral := func(f, g [3]float64) (v float64) {
+				var sf, sg float64
+				for i := range f {
+					v += f[i] * g[i]
+					sf += f[i]
+					sg += g[i]
+				}
+				return math.Abs(area) / 12 * (v + sf*sg)
+			}
+			n += integral(sg, [3]float64{1, 1, 1})
+			mx += integral(sg, y)
+			my += integral(sg, x)
+		}
+		check(t, "N", n, f.N, 1e-6)
+		check(t, "Mx", mx, f.Mx, 1e-6)
+		check(t, "My", my, f.My, 1e-6)
+		// heel of angle
+		var (
+			b    = pr.AtCenterPoint
+			x, y = -pr.X, -pr.Y
+			d    = b.Jxx*b.Jyy - b.Jxy*b.Jxy
+			heel = f.N/pr.A + (f.Mx*b.Jyy-f.My*b.Jxy)/d*y + (f.My*b.Jxx-f.Mx*b.Jxy)/d*x
+			k    = 0
+		)
+		for i, node := range mesh.Nodes {
+			if math.Hypot(node.Coord[0]-x, node.Coord[1]-y) <
+				math.Hypot(mesh.Nodes[k].Coord[0]-x, mesh.Nodes[k].Coord[1]-y) {
+				k = i
+			}
+		}
+		check(t, "heel", s.Sigma[k], heel, 1e-3)
+	})
+}
 
-		c20pr, err := section.GetProperty(c20)
-		s2 := printJson(&c20pr)
+func TestCalculateWithMesh(t *testing.T) {
+	g := section.UPNs[4]
+	pr, err := section.Calculate(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		axes section.Axes
+		x, y float64
+	}{
+		{axes: section.BasePoint, x: pr.X, y: pr.Y},
+		{axes: section.CenterPoint},
+		{axes: section.SectionAxe},
+	} {
+		t.Run(fmt.Sprintf("%d", tc.axes), func(t *testing.T) {
+			p, mesh, err := section.CalculateWithMesh(g, tc.axes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if printJson(p) != printJson(pr) {
+				t.Errorf("property is not same")
+			}
+			a, center := section.Area(*mesh)
+			if eps := 1e-9; eps < math.Abs(a-pr.A) {
+				t.Errorf("area: %e != %e", a, pr.A)
+			}
+			if eps := 1e-9; eps < math.Abs(center.Coord[0]-tc.x) ||
+				eps < math.Abs(center.Coord[1]-tc.y) {
+				t.Errorf("center: %v != (%e,%e)", center.Coord, tc.x, tc.y)
+			}
+		})
+	}
+	t.Run("validate", func(t *testing.T) {
+		for _, tc := range []struct {
+			g    section.Geor
+			axes section.Axes
+		}{
+			{g: g, axes: section.Axes(5)},
+			{g: section.Angle{Width: 0.05}, axes: section.BasePoint},
+		} {
+			p, mesh, err := section.CalculateWithMesh(tc.g, tc.axes)
+			if err == nil || p != nil || mesh != nil {
+				t.Errorf("not valid result: %v %v %v", p, mesh, err)
+			}
+		}
+	})
+}
 
-		if s != s2 {
-			t.Fatalf("diff names")
+func TestPolygon(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect float64) {
+		t.Helper()
+		if eps := 1e-9; eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	t.Run("rectangle", func(t *testing.T) {
+		r := section.Rectangles[3]
+		pr, err := section.CalculateBending(r)
+		if err != nil {
+			t.Fatal(err)
 		}
+		check(t, "A", pr.A, r.H*r.Thk)
+		check(t, "Y", pr.Y, r.H/2.0)
+		check(t, "Jxx", pr.AtCenterPoint.Jxx, r.Thk*math.Pow(r.H, 3)/12.0)
+		check(t, "Jyy", pr.AtCenterPoint.Jyy, r.H*math.Pow(r.Thk, 3)/12.0)
+		check(t, "WxPlastic", pr.AtCenterPoint.WxPlastic, r.Thk*r.H*r.H/4.0)
+		check(t, "WyPlastic", pr.AtCenterPoint.WyPlastic, r.H*r.Thk*r.Thk/4.0)
+		check(t, "Jxx base", pr.AtBasePoint.Jxx, r.Thk*math.Pow(r.H, 3)/3.0)
 	})
-	t.Run("list", func(t *testing.T) {
-		list := section.GetList()
-		var buf bytes.Buffer
-		for i := range list {
-			fmt.Fprintf(&buf, "%s\n", list[i].GetName())
+	t.Run("tsection", func(t *testing.T) {
+		ts := section.Tsection{H: 0.100, Thk: 0.010, L: 0.100, Thk2: 0.020}
+		pr, err := section.CalculateBending(ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// flange is located at y in [-Thk2/2, 0]
+		var (
+			a1 = ts.L * ts.Thk2 / 2.0
+			y1 = -ts.Thk2 / 4.0
+			a2 = ts.H * ts.Thk
+			y2 = ts.H / 2.0
+			yc = (a1*y1 + a2*y2) / (a1 + a2)
+			j  = ts.L*math.Pow(ts.Thk2/2.0, 3)/12.0 + a1*math.Pow(y1-yc, 2) +
+				ts.Thk*math.Pow(ts.H, 3)/12.0 + a2*math.Pow(y2-yc, 2)
+		)
+		check(t, "A", pr.A, a1+a2)
+		check(t, "Y", pr.Y, yc)
+		check(t, "Jxx", pr.AtCenterPoint.Jxx, j)
+		if eps := 1e-9; eps < math.Abs(pr.AtCenterPoint.Jxy) {
+			t.Errorf("Jxy is not zero: %e", pr.AtCenterPoint.Jxy)
 		}
-		compare.Test(t, td(".test.list"), buf.Bytes())
 	})
-	t.Run("plate group", func(t *testing.T) {
-		pg := section.PlateGroup{
-			Name: "test",
-			Plates: []section.Plate{
-				{Xc: 0.0000, Yc: 0.0175, X: 0.3000, Y: 0.0350},
-				{Xc: 0.0000, Yc: 0.8825, X: 0.3000, Y: 0.0350},
-				{Xc: 0.0000, Yc: 0.4500, X: 0.0185, Y: 0.8300},
+	t.Run("section axe", func(t *testing.T) {
+		// moment of inertia around axe X is minimal
+		r := section.Rectangle{H: 0.100, Thk: 0.200}
+		pr, err := section.CalculateBending(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "Jxx", pr.OnSectionAxe.Jxx, r.Thk*math.Pow(r.H, 3)/12.0)
+		check(t, "Jyy", pr.OnSectionAxe.Jyy, r.H*math.Pow(r.Thk, 3)/12.0)
+	})
+	t.Run("mesh", func(t *testing.T) {
+		// product moment of inertia on mesh is same as on contours
+		a := section.Angle{Width: 0.100, Thk: 0.010, Radius1: 0.012, Radius2: 0.006}
+		pr, err := section.CalculateBending(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mesh, err := section.GenerateMsh(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		section.MoveXOY(mesh, -pr.X, -pr.Y)
+		if eps := 1e-9; eps < math.Abs(section.Jxy(*mesh)/pr.AtCenterPoint.Jxy-1) {
+			t.Errorf("Jxy: %e != %e", section.Jxy(*mesh), pr.AtCenterPoint.Jxy)
+		}
+	})
+	t.Run("without contours", func(t *testing.T) {
+		if _, err := section.CalculateBending(section.Gmsh{Geor: section.Isections[0]}); err == nil {
+			t.Errorf("section without contours")
+		}
+	})
+}
+
+func TestCylinder(t *testing.T) {
+	c := section.Cylinder{Od: 0.100, Thk: 0.010}
+	pr, err := section.Calculate(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		r1 = c.Od / 2.0
+		r2 = r1 - c.Thk
+		a  = math.Pi * (r1*r1 - r2*r2)
+		j  = math.Pi / 4.0 * (math.Pow(r1, 4) - math.Pow(r2, 4))
+	)
+	for _, v := range []struct {
+		name           string
+		actual, expect float64
+	}{
+		{"A", pr.A, a},
+		{"Jxx", pr.AtCenterPoint.Jxx, j},
+		{"Jyy", pr.AtCenterPoint.Jyy, j},
+		{"It", pr.Torsion.It, 2 * j},
+	} {
+		if eps := 0.005; eps < math.Abs((v.actual-v.expect)/v.expect) {
+			t.Errorf("%s: %e != %e", v.name, v.actual, v.expect)
+		}
+	}
+}
+
+func TestBoundaryGeo(t *testing.T) {
+	geo := section.Angles[0].Geo(0.001)
+	compare.Test(t, td(".angle.geo"), []byte(geo))
+}
+
+func TestUserPolygon(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect float64) {
+		t.Helper()
+		if eps := 1e-6; eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	t.Run("hole", func(t *testing.T) {
+		p := section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: 0.2, Y: 0}, {X: 0.2, Y: 0.1}, {X: 0, Y: 0.1}},
+			Holes: [][]section.Vertex{
+				{{X: 0.05, Y: 0.025}, {X: 0.05, Y: 0.075}, {X: 0.15, Y: 0.075}, {X: 0.15, Y: 0.025}},
 			},
 		}
-		pr, err := section.Calculate(pg)
+		pr, err := section.Calculate(p)
 		if err != nil {
 			t.Fatal(err)
-			return
 		}
-		compare.Test(t, "test.plate.group", []byte(pr.String()))
+		check(t, "A", pr.A, 0.2*0.1-0.1*0.05)
+		check(t, "X", pr.X, 0.1)
+		check(t, "Jxx", pr.AtCenterPoint.Jxx, 0.2*math.Pow(0.1, 3)/12.0-0.1*math.Pow(0.05, 3)/12.0)
+	})
+	t.Run("fillet", func(t *testing.T) {
+		const r = 0.01
+		p := section.Polygon{
+			Outer: []section.Vertex{
+				{X: 0, Y: 0, Radius: r},
+				{X: 0.2, Y: 0, Radius: r},
+				{X: 0.2, Y: 0.1, Radius: r},
+				{X: 0, Y: 0.1, Radius: r},
+			},
+		}
+		pr, err := section.Calculate(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if eps := 1e-4; eps < math.Abs(pr.A/(0.2*0.1-(4-math.Pi)*r*r)-1) {
+			t.Errorf("A: %e", pr.A)
+		}
+	})
+	for _, tc := range []struct {
+		name string
+		p    section.Polygon
+	}{
+		{"less points", section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: 1, Y: 0}},
+		}},
+		{"clockwise", section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}},
+		}},
+		{"self-intersection", section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}},
+		}},
+		{"spike", section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0.5}},
+		}},
+		{"hole counterclockwise", section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}},
+			Holes: [][]section.Vertex{{{X: 0.2, Y: 0.2}, {X: 0.4, Y: 0.2}, {X: 0.4, Y: 0.4}}},
+		}},
+		{"hole outside", section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}},
+			Holes: [][]section.Vertex{{{X: 2.2, Y: 0.2}, {X: 2.4, Y: 0.4}, {X: 2.4, Y: 0.2}}},
+		}},
+		{"hole intersection", section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}},
+			Holes: [][]section.Vertex{{{X: 0.5, Y: 0.5}, {X: 1.5, Y: 0.6}, {X: 1.5, Y: 0.5}}},
+		}},
+		{"big fillet", section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0, Radius: 0.6}, {X: 1, Y: 0, Radius: 0.6}, {X: 1, Y: 1}, {X: 0, Y: 1}},
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.p.Validate()
+			if err == nil {
+				t.Fatalf("error is not found")
+			}
+			if _, err2 := section.Calculate(tc.p); err2 == nil {
+				t.Fatalf("error is not found in calculation")
+			}
+			t.Log(err)
+		})
+	}
+}
+
+func TestHollow(t *testing.T) {
+	// values from tables of EN 10210-2
+	for _, tc := range []struct {
+		name       string
+		a, j, w, i float64 // cm2, cm4, cm3, cm4
+	}{
+		{name: "SHS 100x100x5.0 EN 10210-2", a: 18.7, j: 279, w: 66.4, i: 445},
+		{name: "SHS 200x200x10.0 EN 10210-2", a: 74.9, j: 4471, w: 531, i: 7031},
+		{name: "CHS 114.3x6.3", a: 21.4, j: 313, w: 73.6, i: 626},
+		{name: "CHS 168.3x8.0", a: 40.3, j: 1297, w: 207, i: 2595},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := section.Get(tc.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pr, err := section.Calculate(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range []struct {
+				name           string
+				actual, expect float64
+			}{
+				{"A", pr.A * 1e4, tc.a},
+				{"Jxx", pr.AtCenterPoint.Jxx * 1e8, tc.j},
+				{"WxPlastic", pr.AtCenterPoint.WxPlastic * 1e6, tc.w},
+				{"It", pr.Torsion.It * 1e8, tc.i},
+			} {
+				if eps := 0.02; eps < math.Abs((v.actual-v.expect)/v.expect) {
+					t.Errorf("%s: %.2f != %.2f", v.name, v.actual, v.expect)
+				}
+			}
+		})
+	}
+	t.Run("validate", func(t *testing.T) {
+		r := section.RHS{H: 0.1, B: 0.1, Thk: 0.06}
+		if err := r.Validate(); err == nil {
+			t.Errorf("error is not found")
+		}
 	})
 }
 
-// cpu: Intel(R) Xeon(R) CPU E3-1240 V2 @ 3.40GHz
-// Benchmark/Get-4         	 5738064	        212.8 ns/op	      64 B/op	       1 allocs/op
-// Benchmark/Calculate-4   	       4	    284656617 ns/op	 1186370 B/op	   10855 allocs/op
-// Benchmark/GetProperty-4 	37749807	        31.03 ns/op	       0 B/op	       0 allocs/op
-//
-// cpu: Intel(R) Xeon(R) CPU           X5550  @ 2.67GHz
-// Benchmark/Get-8         	   75926	     18192 ns/op	    9520 B/op	      98 allocs/op
-// Benchmark/Calculate-8   	       4	 286957623 ns/op	 1276140 B/op	   10995 allocs/op
-// Benchmark/GetProperty-8 	13540945	        79.70 ns/op	       0 B/op	       0 allocs/op
-//
-// Benchmark/Get/0-8       	   69296	     18588 ns/op	    9520 B/op	      98 allocs/op
-// Benchmark/Get/1-8       	   63228	     18566 ns/op	    9520 B/op	      98 allocs/op
-// Benchmark/Calculate/0-8 	       4	 286663606 ns/op	 1274800 B/op	   10997 allocs/op
-// Benchmark/Calculate/1-8 	       7	 150331238 ns/op	  157937 B/op	     664 allocs/op
-// Benchmark/Property/0-8  	13376570	        78.90 ns/op	       0 B/op	       0 allocs/op
-// Benchmark/Property/1-8  	14337594	        81.01 ns/op	       0 B/op	       0 allocs/op
+func TestBredt(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	t.Run("cylinder", func(t *testing.T) {
+		for _, c := range []section.Cylinder{
+			{Od: 0.200, Thk: 0.005},
+			{Od: 0.1143, Thk: 0.0063},
+		} {
+			pr, err := section.Calculate(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tr := pr.Torsion
+			if len(tr.Cells) != 1 || len(tr.Walls) != 1 {
+				t.Fatalf("not valid cells: %v", tr)
+			}
+			var (
+				r = (c.Od - c.Thk) / 2.0
+				a = math.Pi * r * r
+			)
+			check(t, "ItBredt", tr.ItBredt, 2*math.Pi*math.Pow(r, 3)*c.Thk, 0.005)
+			check(t, "It", tr.ItBredt, tr.It, 0.005)
+			check(t, "Area", tr.Cells[0].Area, a, 0.005)
+			check(t, "Flow", tr.Walls[0].Flow, 1/(2*a), 0.005)
+		}
+	})
+	t.Run("solid", func(t *testing.T) {
+		pr, err := section.Calculate(section.Cylinder{Od: 0.1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tr := pr.Torsion; tr.ItBredt != 0 || len(tr.Cells) != 0 || len(tr.Walls) != 0 {
+			t.Errorf("closed cells in solid section: %v", tr)
+		}
+	})
+	t.Run("two cells", func(t *testing.T) {
+		// box with web, thickness of walls `tf` and web `tw`
+		const (
+			b1, b2, h = 0.120, 0.080, 0.100
+			tf, tw    = 0.004, 0.006
+		)
+		p := section.Polygon{
+			Outer: []section.Vertex{{X: 0, Y: 0}, {X: b1 + b2, Y: 0}, {X: b1 + b2, Y: h}, {X: 0, Y: h}},
+			Holes: [][]section.Vertex{
+				{{X: tf, Y: tf}, {X: tf, Y: h - tf}, {X: b1 - tw/2, Y: h - tf}, {X: b1 - tw/2, Y: tf}},
+				{{X: b1 + tw/2, Y: tf}, {X: b1 + tw/2, Y: h - tf}, {X: b1 + b2 - tf, Y: h - tf}, {X: b1 + b2 - tf, Y: tf}},
+			},
+		}
+		pr, err := section.Calculate(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr := pr.Torsion
+		if len(tr.Cells) != 2 || len(tr.Walls) != 3 {
+			t.Fatalf("not valid cells: %v", tr)
+		}
+		// Bredt-Batho on median line
+		var (
+			hm  = h - tf
+			w1  = b1 - tf/2
+			w2  = b2 - tf/2
+			a1  = w1 * hm
+			a2  = w2 * hm
+			k11 = (2*w1+hm)/tf + hm/tw
+			k22 = (2*w2+hm)/tf + hm/tw
+			k12 = -hm / tw
+			det = k11*k22 - k12*k12
+			q1  = (2*a1*k22 - 2*a2*k12) / det
+			q2  = (2*a2*k11 - 2*a1*k12) / det
+			it  = 2 * (a1*q1 + a2*q2)
+		)
+		check(t, "ItBredt", tr.ItBredt, it, 0.02)
+		check(t, "It", tr.ItBredt, tr.It, 0.02)
+		check(t, "Area 0", tr.Cells[0].Area, a1, 0.01)
+		check(t, "Area 1", tr.Cells[1].Area, a2, 0.01)
+		for _, w := range tr.Walls {
+			var expect float64
+			switch w.Cells {
+			case [2]int{0, 1}:
+				expect = (q1 - q2) / it
+			case [2]int{0, -1}:
+				expect = q1 / it
+			case [2]int{1, -1}:
+				expect = q2 / it
+			default:
+				t.Fatalf("not valid wall: %v", w.Cells)
+			}
+			check(t, fmt.Sprintf("Flow %v", w.Cells), w.Flow, expect, 0.03)
+		}
+	})
+}
+
+func TestUnequalAngle(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	// values of tables
+	for _, tc := range []struct {
+		name              string
+		a, jx, jy, ju, jv float64
+		tan               float64
+	}{
+		{"L100x50x6 EN 10056-1", 8.71e-4, 89.9e-8, 15.4e-8, 95.4e-8, 9.92e-8, 0.263},
+		{"L63x40x5 GOST 8510", 4.98e-4, 19.91e-8, 6.26e-8, 22.44e-8, 3.73e-8, 0.396},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := section.Get(tc.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pr, err := section.Calculate(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			check(t, "A", pr.A, tc.a, 0.01)
+			check(t, "Jxx", pr.AtCenterPoint.Jxx, tc.jx, 0.01)
+			check(t, "Jyy", pr.AtCenterPoint.Jyy, tc.jy, 0.01)
+			check(t, "Ju", pr.Principal.Ju, tc.ju, 0.01)
+			check(t, "Jv", pr.Principal.Jv, tc.jv, 0.01)
+			check(t, "TanAlpha", pr.Principal.TanAlpha, tc.tan, 0.01)
+			// extreme fibres
+			pp := pr.Principal
+			check(t, "Wu", pp.Wu, pp.Ju/math.Max(pp.Top.V, -pp.Bottom.V), 1e-9)
+			check(t, "Wv", pp.Wv, pp.Jv/math.Max(pp.Right.U, -pp.Left.U), 1e-9)
+			for _, f := range []section.Fibre{pp.Top, pp.Bottom, pp.Right, pp.Left} {
+				var (
+					x = f.X - pr.X
+					y = f.Y - pr.Y
+					s = math.Sin(pp.Alpha)
+					c = math.Cos(pp.Alpha)
+				)
+				check(t, "U", x*c+y*s, f.U, 1e-9)
+				check(t, "V", -x*s+y*c, f.V, 1e-9)
+			}
+			if !(pp.Bottom.V < 0 && 0 < pp.Top.V && pp.Left.U < 0 && 0 < pp.Right.U) {
+				t.Errorf("not valid extreme fibres:\n%s", pp)
+			}
+		})
+	}
+	t.Run("equal legs", func(t *testing.T) {
+		a := section.UnequalAngle{Width1: 0.050, Width2: 0.050, Thk: 0.005, Radius1: 0.007, Radius2: 0.0035}
+		pr, err := section.Calculate(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "TanAlpha", pr.Principal.TanAlpha, 1, 1e-6)
+		pe, err := section.Calculate(section.Angle{Width: 0.050, Thk: 0.005, Radius1: 0.007, Radius2: 0.0035})
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "Ju", pr.Principal.Ju, pe.Principal.Ju, 1e-6)
+		check(t, "Jv", pr.Principal.Jv, pe.Principal.Jv, 1e-6)
+	})
+	t.Run("validate", func(t *testing.T) {
+		for _, a := range []section.UnequalAngle{
+			{Width1: 0.1, Width2: 0, Thk: 0.01},
+			{Width1: 0.1, Width2: 0.05, Thk: 0.05},
+			{Width1: 0.1, Width2: 0.05, Thk: 0.01, Radius1: -0.01},
+			{Width1: 0.1, Width2: 0.05, Thk: 0.01, Radius2: 0.02},
+			{Width1: 0.1, Width2: 0.05, Thk: 0.01, Radius1: 0.045},
+		} {
+			if _, err := section.Calculate(a); err == nil {
+				t.Errorf("section is not valid: %#v", a)
+			}
+		}
+	})
+	t.Run("unique names", func(t *testing.T) {
+		names := map[string]bool{}
+		for _, g := range section.GetList() {
+			if names[g.GetName()] {
+				t.Errorf("duplicate name: %s", g.GetName())
+			}
+			names[g.GetName()] = true
+		}
+	})
+}
+
+func TestCompound(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	calc := func(t *testing.T, g section.Geor) *section.Property {
+		t.Helper()
+		pr, err := section.Calculate(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pr
+	}
+	const gap = 0.010
+	t.Run("channels", func(t *testing.T) {
+		u := section.UPNs[0]
+		pu := calc(t, u)
+		for _, tc := range []struct {
+			toeToToe bool
+			e        float64 // distance from center of channel to axe Y
+		}{
+			{false, pu.X + gap/2},
+			{true, u.B - pu.X + gap/2},
+		} {
+			pr := calc(t, section.DoubleChannels(u, gap, tc.toeToToe))
+			check(t, "A", pr.A, 2*pu.A, 1e-6)
+			check(t, "Y", pr.Y, pu.Y, 1e-6)
+			check(t, "Jxx", pr.AtCenterPoint.Jxx, 2*pu.AtCenterPoint.Jxx, 1e-6)
+			check(t, "Jyy", pr.AtCenterPoint.Jyy,
+				2*(pu.AtCenterPoint.Jyy+pu.A*tc.e*tc.e), 1e-6)
+			check(t, "It", pr.Torsion.It, 2*pu.Torsion.It, 0.01)
+			if 1e-9 < math.Abs(pr.X) {
+				t.Errorf("not symmetrical: %e", pr.X)
+			}
+		}
+	})
+	t.Run("double channels", func(t *testing.T) {
+		g, err := section.Get(section.UPNs[0].Name + ",Double")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			pr = calc(t, g)
+			pu = calc(t, section.UPNs[0])
+		)
+		check(t, "A", pr.A, 2*pu.A, 1e-6)
+		check(t, "Jyy", pr.AtCenterPoint.Jyy,
+			2*(pu.AtCenterPoint.Jyy+pu.A*pu.X*pu.X), 1e-6)
+		if pr.Torsion.It < 2*pu.Torsion.It {
+			t.Errorf("connected webs: %e < %e", pr.Torsion.It, 2*pu.Torsion.It)
+		}
+	})
+	t.Run("angles", func(t *testing.T) {
+		a := section.Angles[0]
+		pa := calc(t, a)
+		pr := calc(t, section.BackToBackAngles(a, gap))
+		check(t, "A", pr.A, 2*pa.A, 1e-6)
+		check(t, "Jxx", pr.AtCenterPoint.Jxx, 2*pa.AtCenterPoint.Jxx, 1e-6)
+		check(t, "Jyy", pr.AtCenterPoint.Jyy,
+			2*(pa.AtCenterPoint.Jyy+pa.A*math.Pow(pa.X+gap/2, 2)), 1e-6)
+
+		ps := calc(t, section.StarAngles(a, gap))
+		check(t, "A", ps.A, 2*pa.A, 1e-6)
+		check(t, "Jxx", ps.AtCenterPoint.Jxx, ps.AtCenterPoint.Jyy, 1e-6)
+		check(t, "Jxx", ps.AtCenterPoint.Jxx,
+			2*(pa.AtCenterPoint.Jxx+pa.A*math.Pow(pa.Y+gap/2, 2)), 1e-6)
+		check(t, "TanAlpha", math.Abs(ps.Principal.TanAlpha), 1, 1e-6)
+	})
+	t.Run("validate", func(t *testing.T) {
+		for _, c := range []section.Compound{
+			{},
+			{Parts: []section.Part{{Geor: section.Gmsh{Geor: section.Angles[0]}}}},
+			{Parts: []section.Part{{Geor: section.Angle{Width: 0.05}}}},
+			// overlapped parts
+			{Parts: []section.Part{{Geor: section.Angles[0]}, {Geor: section.Angles[0]}}},
+			{Parts: []section.Part{{Geor: section.Angles[0]}, {Geor: section.Angles[0], X: 0.002, Y: 0.002}}},
+			{Parts: []section.Part{
+				{Geor: section.Rectangle{H: 0.2, Thk: 0.1}},
+				{Geor: section.Rectangle{H: 0.05, Thk: 0.02}, Y: 0.05},
+			}},
+		} {
+			if _, err := section.Calculate(c); err == nil {
+				t.Errorf("section is not valid: %#v", c)
+			}
+		}
+		// parts with common edge
+		if err := section.BackToBackAngles(section.Angles[0], 0).Validate(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestTransform(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect float64) {
+		t.Helper()
+		if eps := 1e-6; eps < math.Abs(actual-expect)/math.Max(math.Abs(expect), 1e-9) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	// shear center by finite element depends on mesh
+	checkFE := func(t *testing.T, name string, actual, expect float64) {
+		t.Helper()
+		if eps := 1e-5; eps < math.Abs(actual-expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	calc := func(t *testing.T, g section.Geor) *section.Property {
+		t.Helper()
+		pr, err := section.Calculate(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pr
+	}
+	for _, g := range []section.Geor{
+		section.Angles[0],
+		section.UnequalAngles[0],
+		section.UPNs[0],
+		section.Tsection{H: 0.2, Thk: 0.01, L: 0.15, Thk2: 0.012},
+	} {
+		t.Run(g.GetName(), func(t *testing.T) {
+			var (
+				p = calc(t, g)
+				c = p.AtCenterPoint
+				b = p.AtBasePoint
+			)
+			// mirror by axe Y
+			m := calc(t, section.Mirror{Geor: g})
+			check(t, "A", m.A, p.A)
+			check(t, "X", m.X, -p.X)
+			check(t, "Y", m.Y, p.Y)
+			check(t, "Jxx", m.AtCenterPoint.Jxx, c.Jxx)
+			check(t, "Jyy", m.AtCenterPoint.Jyy, c.Jyy)
+			check(t, "Jxy", m.AtCenterPoint.Jxy, -c.Jxy)
+			check(t, "Jxy base", m.AtBasePoint.Jxy, -b.Jxy)
+			check(t, "Ju", m.Principal.Ju, p.Principal.Ju)
+			check(t, "Jv", m.Principal.Jv, p.Principal.Jv)
+			// mirror by axe X
+			mx := calc(t, section.Mirror{Geor: g, ByAxeX: true})
+			check(t, "X", mx.X, p.X)
+			check(t, "Y", mx.Y, -p.Y)
+			check(t, "Jxx", mx.AtCenterPoint.Jxx, c.Jxx)
+			check(t, "Jxy", mx.AtCenterPoint.Jxy, -c.Jxy)
+			// rotate on 180 degree
+			r := calc(t, section.Rotate{Geor: g, Angle: math.Pi})
+			check(t, "X", r.X, -p.X)
+			check(t, "Y", r.Y, -p.Y)
+			check(t, "Jxx", r.AtCenterPoint.Jxx, c.Jxx)
+			check(t, "Jyy", r.AtCenterPoint.Jyy, c.Jyy)
+			check(t, "Jxy", r.AtCenterPoint.Jxy, c.Jxy)
+			check(t, "Jxx base", r.AtBasePoint.Jxx, b.Jxx)
+			checkFE(t, "Xs", r.AtBasePoint.Xs, -b.Xs)
+			// rotate on 90 degree
+			r90 := calc(t, section.Rotate{Geor: g, Angle: math.Pi / 2})
+			check(t, "X", r90.X, -p.Y)
+			check(t, "Y", r90.Y, p.X)
+			check(t, "Jxx", r90.AtCenterPoint.Jxx, c.Jyy)
+			check(t, "Jyy", r90.AtCenterPoint.Jyy, c.Jxx)
+			check(t, "Jxy", r90.AtCenterPoint.Jxy, -c.Jxy)
+			// translate
+			const dx, dy = 0.1, -0.2
+			tr := calc(t, section.Translate{Geor: g, X: dx, Y: dy})
+			check(t, "X", tr.X, p.X+dx)
+			check(t, "Y", tr.Y, p.Y+dy)
+			check(t, "Jxx", tr.AtCenterPoint.Jxx, c.Jxx)
+			check(t, "Jyy", tr.AtCenterPoint.Jyy, c.Jyy)
+			check(t, "Jxy", tr.AtCenterPoint.Jxy, c.Jxy)
+			check(t, "Jxx base", tr.AtBasePoint.Jxx, c.Jxx+p.A*tr.Y*tr.Y)
+			check(t, "Jyy base", tr.AtBasePoint.Jyy, c.Jyy+p.A*tr.X*tr.X)
+			check(t, "Jxy base", tr.AtBasePoint.Jxy, c.Jxy+p.A*tr.X*tr.Y)
+			checkFE(t, "Xs", tr.AtBasePoint.Xs, b.Xs+dx)
+			checkFE(t, "Ys", tr.AtBasePoint.Ys, b.Ys+dy)
+		})
+	}
+	t.Run("validate", func(t *testing.T) {
+		for _, g := range []section.Geor{
+			section.Mirror{Geor: section.Gmsh{Geor: section.Angles[0]}},
+			section.Rotate{Geor: section.RHS{H: 0.1, B: 0.1, Thk: 0.1}},
+			section.Translate{Geor: section.Gmsh{Geor: section.Angles[0]}},
+		} {
+			if _, err := section.Calculate(g); err == nil {
+				t.Errorf("section is not valid: %s", g.GetName())
+			}
+		}
+	})
+}
+
+func TestColdFormed(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs(actual-expect)/math.Max(math.Abs(expect), 1e-9) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	const (
+		h, b, c, thk, r = 0.100, 0.050, 0.020, 0.004, 0.004
+		rm              = r + thk/2
+	)
+	// area by center line with bends on angle `a`
+	area := func(length float64, a ...float64) float64 {
+		for _, a := range a {
+			length += -2*rm*math.Tan(a/2) + rm*a
+		}
+		return thk * length
+	}
+	var (
+		right = math.Pi / 2
+		web   = h - thk
+		lip   = c - thk/2
+	)
+	t.Run("channel", func(t *testing.T) {
+		pr, err := section.Calculate(section.LippedChannel{H: h, B: b, C: c, Thk: thk, R: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "A", pr.A, area(web+2*(b-thk)+2*lip, right, right, right, right), 1e-3)
+		check(t, "Y", pr.Y, h/2, 1e-6)
+		check(t, "Jxy", pr.AtCenterPoint.Jxy, 0, 1e-6)
+		if 0 <= pr.AtBasePoint.Xs {
+			t.Errorf("shear center is not behind the web: %e", pr.AtBasePoint.Xs)
+		}
+	})
+	t.Run("Z", func(t *testing.T) {
+		pr, err := section.Calculate(section.LippedZ{H: h, B1: b, B2: b, C: c, Thk: thk, R: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "A", pr.A, area(web+2*(b-thk)+2*lip, right, right, right, right), 1e-3)
+		check(t, "X", pr.X, thk/2, 1e-6)
+		check(t, "Y", pr.Y, h/2, 1e-6)
+		pu, err := section.Calculate(section.LippedZ{H: h, B1: b, B2: b + 0.010, C: c, Thk: thk, R: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "A", pu.A, pr.A+0.010*thk, 1e-3)
+	})
+	t.Run("sigma", func(t *testing.T) {
+		const d, s = 0.010, 0.030
+		pr, err := section.Calculate(section.Sigma{H: h, B: b, C: c, D: d, S: s, Thk: thk, R: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			length = 2*(s-thk/2) + 2*math.Sqrt2*d + (h - 2*s - 2*d) + 2*(b-thk) + 2*lip
+			q      = math.Pi / 4
+		)
+		check(t, "A", pr.A, area(length, right, right, right, right, q, q, q, q), 1e-3)
+		check(t, "Y", pr.Y, h/2, 1e-6)
+	})
+	t.Run("hat", func(t *testing.T) {
+		const f = 0.030
+		pr, err := section.Calculate(section.Hat{H: h / 2, B: b, F: f, Thk: thk, R: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "A", pr.A, area(2*(f+thk/2)+2*(h/2-thk)+(b-thk), right, right, right, right), 1e-3)
+		check(t, "X", pr.X, f+b/2, 1e-6)
+	})
+	t.Run("catalog", func(t *testing.T) {
+		var list []interface{ Validate() error }
+		for _, v := range section.LippedChannels {
+			list = append(list, v)
+		}
+		for _, v := range section.LippedZs {
+			list = append(list, v)
+		}
+		for _, v := range section.Sigmas {
+			list = append(list, v)
+		}
+		for _, v := range section.Hats {
+			list = append(list, v)
+		}
+		for _, v := range list {
+			if err := v.Validate(); err != nil {
+				t.Errorf("%v: %v", v, err)
+			}
+		}
+	})
+	t.Run("validate", func(t *testing.T) {
+		for _, g := range []section.Geor{
+			section.LippedChannel{H: h, B: b, C: c, Thk: 0, R: r},
+			section.LippedChannel{H: h, B: b, C: c, Thk: thk, R: -r},
+			section.LippedChannel{H: h, B: b, C: thk, Thk: thk, R: r},
+			section.LippedZ{H: h, B1: b, B2: 0, C: c, Thk: thk, R: r},
+			section.Sigma{H: h, B: b, C: c, D: b, S: 0.030, Thk: thk, R: r},
+			section.Hat{H: h, B: b, F: 0.001, Thk: thk, R: r},
+		} {
+			if _, err := section.Calculate(g); err == nil {
+				t.Errorf("section is not valid: %s", g.GetName())
+			}
+		}
+	})
+}
+
+func TestFiniteStrip(t *testing.T) {
+	const e, nu = 2e11, 0.3
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	t.Run("plate", func(t *testing.T) {
+		// column from one plate without Poisson's effect
+		const b, thk, l = 0.100, 0.005, 2.0
+		m := section.StripModel{
+			Nodes:  []section.Point{{X: 0, Y: 0}, {X: b, Y: 0}},
+			Strips: []section.Strip{{Begin: 0, End: 1, Thk: thk}},
+		}
+		res, err := section.FiniteStrip(m, section.Compression, e, 0, []float64{l})
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "Pcr", res.Curve[0].Factor, math.Pi*math.Pi*e*b*math.Pow(thk, 3)/12/l/l, 1e-6)
+	})
+	t.Run("SHS", func(t *testing.T) {
+		var (
+			r  = section.RHS{H: 0.200, B: 0.200, Thk: 0.004}
+			bm = r.B - r.Thk
+			a  = 4 * bm * r.Thk
+		)
+		res, err := section.FiniteStrip(r.StripModel(), section.Compression, e, nu, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// local buckling of plate with simply supported edges
+		check(t, "Local", res.Local.Factor/a,
+			4*math.Pi*math.Pi*e/12/(1-nu*nu)*math.Pow(r.Thk/bm, 2), 0.01)
+		check(t, "Length", res.Local.Length, bm, 0.1)
+		// flexural buckling
+		const l = 10.0
+		res, err = section.FiniteStrip(r.StripModel(), section.Compression, e, nu, []float64{l})
+		if err != nil {
+			t.Fatal(err)
+		}
+		j := r.Thk * (math.Pow(bm, 3)/6 + math.Pow(bm, 3)/2)
+		check(t, "Euler", res.Curve[0].Factor, math.Pi*math.Pi*e*j/l/l, 0.01)
+	})
+	t.Run("lateral torsional", func(t *testing.T) {
+		var (
+			is = section.Isection{H: 0.300, B: 0.150, Tw: 0.007, Tf: 0.0107}
+			h  = is.H - is.Tf
+			iz = 2*is.Tf*math.Pow(is.B, 3)/12 + h*math.Pow(is.Tw, 3)/12
+			it = (2*is.B*math.Pow(is.Tf, 3) + h*math.Pow(is.Tw, 3)) / 3
+			iw = 2 * is.Tf * math.Pow(is.B, 3) / 12 * h * h / 4
+			g  = e / 2 / (1 + nu)
+		)
+		for _, l := range []float64{6, 12} {
+			res, err := section.FiniteStrip(is.StripModel(), section.BendingX, e, nu, []float64{l})
+			if err != nil {
+				t.Fatal(err)
+			}
+			mcr := math.Pi / l * math.Sqrt(e*iz*g*it) * math.Sqrt(1+math.Pi*math.Pi*e*iw/l/l/g/it)
+			check(t, fmt.Sprintf("Mcr %g", l), res.Curve[0].Factor, mcr, 0.01)
+		}
+	})
+	t.Run("lipped channel", func(t *testing.T) {
+		for _, load := range []section.Load{section.Compression, section.BendingX, section.BendingY} {
+			res, err := section.FiniteStrip(section.LippedChannels[3].StripModel(), load, e, nu, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// local mode on short half-wavelength and distortional mode
+			// with movement of lips on longer half-wavelength
+			if res.Local.Length == 0 || res.Distortional.Length == 0 {
+				t.Fatalf("%s: modes are not found:\n%s", load, res)
+			}
+			if h := section.LippedChannels[3].H; !(res.Local.Length < h && 2*h < res.Distortional.Length) {
+				t.Errorf("%s: modes are not valid:\n%s", load, res)
+			}
+			if last := res.Curve[len(res.Curve)-1]; res.Distortional.Factor < last.Factor {
+				t.Errorf("%s: global buckling is not minimal", load)
+			}
+		}
+	})
+	t.Run("models", func(t *testing.T) {
+		for _, s := range []section.Striper{
+			section.Angles[0],
+			section.UnequalAngles[0],
+			section.Isections[0],
+			section.UPNs[0],
+			section.Tsection{H: 0.2, Thk: 0.01, L: 0.15, Thk2: 0.024},
+			section.RHSs[0],
+			section.LippedChannels[0],
+			section.LippedZs[0],
+			section.Sigmas[0],
+			section.Hats[0],
+		} {
+			if _, err := section.FiniteStrip(s.StripModel(), section.Compression, e, nu, nil); err != nil {
+				t.Errorf("%v: %v", s, err)
+			}
+		}
+	})
+	t.Run("validate", func(t *testing.T) {
+		m := section.LippedChannels[0].StripModel()
+		for _, tc := range []struct {
+			m       section.StripModel
+			e, nu   float64
+			lengths []float64
+		}{
+			{section.StripModel{}, e, nu, nil},
+			{section.StripModel{Nodes: m.Nodes, Strips: []section.Strip{{Begin: 0, End: 100, Thk: 0.001}}}, e, nu, nil},
+			{section.StripModel{Nodes: m.Nodes, Strips: []section.Strip{{Begin: 0, End: 1}}}, e, nu, nil},
+			{section.StripModel{Nodes: m.Nodes, Strips: []section.Strip{{Begin: 1, End: 1, Thk: 0.001}}}, e, nu, nil},
+			{m, -e, nu, nil},
+			{m, e, 0.5, nil},
+			{m, e, nu, []float64{0}},
+		} {
+			if _, err := section.FiniteStrip(tc.m, section.Compression, tc.e, tc.nu, tc.lengths); err == nil {
+				t.Errorf("model is not valid: %v", tc)
+			}
+		}
+	})
+}
+
+func TestClassify(t *testing.T) {
+	ipe, err := section.Get("IPE300")
+	if err != nil {
+		t.Fatal(err)
+	}
+	girder := section.PlateGroup{
+		Name: "welded girder",
+		Plates: []section.Plate{
+			{Xc: 0, Yc: 0.010, X: 0.300, Y: 0.020},
+			{Xc: 0, Yc: 0.770, X: 0.008, Y: 1.500},
+			{Xc: 0, Yc: 1.530, X: 0.300, Y: 0.020},
+		},
+	}
+	for _, tc := range []struct {
+		g     section.Geor
+		load  section.Load
+		fy    float64
+		class int
+	}{
+		// web c/t = 248.6/7.1 = 35.0
+		{ipe, section.Compression, 235e6, 2},
+		{ipe, section.Compression, 355e6, 4},
+		{ipe, section.BendingX, 355e6, 1},
+		{ipe, section.BendingY, 355e6, 1},
+		// web c/t = 187.5 > 124*0.81
+		{girder, section.BendingX, 355e6, 4},
+		{girder, section.BendingY, 355e6, 1},
+		// angle in compression is not better class 3
+		{section.Angles[0], section.Compression, 235e6, 3},
+		{section.UnequalAngles[0], section.BendingX, 235e6, 1},
+		{section.UPNs[0], section.Compression, 235e6, 1},
+		{section.Tsection{H: 0.2, Thk: 0.01, L: 0.15, Thk2: 0.024}, section.BendingX, 235e6, 4},
+	} {
+		c, err := section.Classify(tc.g, tc.load, tc.fy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Class != tc.class {
+			t.Errorf("%s: class %d != %d\n%s", tc.g.GetName(), c.Class, tc.class, c)
+		}
+	}
+	t.Run("girder", func(t *testing.T) {
+		c, err := section.Classify(girder, section.BendingX, 355e6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c.Elements) != 5 {
+			t.Fatalf("amount of elements: %d", len(c.Elements))
+		}
+		for _, e := range c.Elements {
+			switch e.Kind {
+			case section.Internal:
+				if math.Abs(e.C-1.5) > 1e-9 || math.Abs(e.Psi+1) > 1e-6 || math.Abs(e.Alpha-0.5) > 1e-6 {
+					t.Errorf("web: %#v", e)
+				}
+			case section.Outstand:
+				if math.Abs(e.C-0.146) > 1e-9 {
+					t.Errorf("flange: %#v", e)
+				}
+			}
+		}
+		compare.Test(t, td(".classify"), []byte(c.String()))
+	})
+	t.Run("validate", func(t *testing.T) {
+		if _, err := section.Classify(section.Cylinder{Od: 0.1, Thk: 0.005}, section.Compression, 235e6); err == nil {
+			t.Errorf("section without elements")
+		}
+		if _, err := section.Classify(ipe, section.Compression, 0); err == nil {
+			t.Errorf("zero yield strength")
+		}
+	})
+}
+
+func TestEffective(t *testing.T) {
+	girder := section.PlateGroup{
+		Name: "welded girder",
+		Plates: []section.Plate{
+			{Xc: 0, Yc: 0.010, X: 0.300, Y: 0.020},
+			{Xc: 0, Yc: 0.770, X: 0.008, Y: 1.500},
+			{Xc: 0, Yc: 1.530, X: 0.300, Y: 0.020},
+		},
+	}
+	const fy = 355e6
+	t.Run("compression", func(t *testing.T) {
+		ef, err := section.Effective(girder, section.Compression, fy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// web: k = 4, rho = (lambda-0.22)/lambda^2, flanges are effective
+		var (
+			eps    = math.Sqrt(235e6 / fy)
+			lambda = 1.5 / 0.008 / (28.4 * eps * 2)
+			rho    = (lambda - 0.22) / lambda / lambda
+			aeff   = 2*0.300*0.020 + rho*1.5*0.008
+		)
+		if math.Abs(ef.Aeff-aeff) > 1e-9 {
+			t.Errorf("Aeff: %e != %e", ef.Aeff, aeff)
+		}
+		if math.Abs(ef.ENx) > 1e-9 || math.Abs(ef.ENy) > 1e-9 {
+			t.Errorf("shift of center: %e %e", ef.ENx, ef.ENy)
+		}
+		if len(ef.Section.Plates) != 4 {
+			t.Errorf("web is not divided:\n%s", ef.Section.GetName())
+		}
+	})
+	t.Run("bending", func(t *testing.T) {
+		ef, err := section.Effective(girder, section.BendingX, fy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			a = 2*0.300*0.020 + 1.5*0.008
+			j = 2*0.300*0.020*0.76*0.76 + 0.008*math.Pow(1.5, 3)/12
+			w = j / 0.77
+		)
+		// compression part of web is reduced, center is moved down
+		if !(ef.ENy < 0 && ef.Aeff < a && ef.Weff < w) {
+			t.Errorf("not valid effective section:\n%s", ef)
+		}
+		// stresses of web for converged center of effective section
+		yc := 0.77 + ef.ENy
+		for _, e := range ef.Elements {
+			if e.Kind != section.Internal {
+				continue
+			}
+			if psi := (0.02 - yc) / (1.52 - yc); math.Abs(e.Psi-psi) > 1e-5 || !(e.Rho < 1) {
+				t.Errorf("web: %#v, psi = %e", e, psi)
+			}
+		}
+		// the first pass with gross web, psi = -1
+		var (
+			eps    = math.Sqrt(235e6 / fy)
+			lambda = 1.5 / 0.008 / (28.4 * eps * math.Sqrt(23.9))
+			rho    = (lambda - 0.11) / lambda / lambda
+			beff   = rho * 0.75
+			ah     = (0.75 - beff) * 0.008
+			yh     = 1.52 - 0.4*beff - (0.75-beff)/2
+			en     = -ah * (yh - 0.77) / (a - ah)
+		)
+		if !(ef.ENy < en-1e-3) {
+			t.Errorf("neutral axe is not iterated: %e, first pass %e", ef.ENy, en)
+		}
+		compare.Test(t, td(".effective"), []byte(ef.String()))
+	})
+	t.Run("compression and bending", func(t *testing.T) {
+		// stresses in web of gross section: N/A = 1, M*0.75/J = 3, so
+		// psi = -0.5 for the first pass
+		var (
+			a = 2*0.300*0.020 + 1.5*0.008
+			j = 2*0.300*(0.020*0.76*0.76+math.Pow(0.020, 3)/12) + 0.008*math.Pow(1.5, 3)/12
+			f = section.Forces{N: -a, Mx: -3 * j / 0.75}
+		)
+		ef, err := section.EffectiveForce(girder, f, fy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// stresses of web for converged center of effective section
+		var (
+			yc     = ef.Property.Y
+			stress = func(y float64) float64 {
+				return -f.N/ef.Aeff - f.Mx*(y-yc)/ef.Property.AtCenterPoint.Jxx
+			}
+			psi = stress(0.02) / stress(1.52)
+		)
+		// center is moved down, so tension of web is decreased
+		if !(-0.5+0.1 < psi && psi < 0) {
+			t.Errorf("neutral axe is not iterated: %e", psi)
+		}
+		var web section.EffectiveElement
+		for _, e := range ef.Elements {
+			if e.Web != (e.Kind == section.Internal) {
+				t.Errorf("not valid web: %#v", e)
+			}
+			if !e.Web {
+				// flanges by gross section
+				if e.Psi != 0 && math.Abs(e.Psi-1) > 1e-9 {
+					t.Errorf("flange: %#v", e)
+				}
+				continue
+			}
+			web = e
+		}
+		if math.Abs(web.Psi-psi) > 1e-5 {
+			t.Errorf("web is not converged: %e != %e", web.Psi, psi)
+		}
+		psi = web.Psi
+		var (
+			eps    = math.Sqrt(235e6 / fy)
+			k      = 7.81 - 6.29*psi + 9.78*psi*psi
+			lambda = 1.5 / 0.008 / (28.4 * eps * math.Sqrt(k))
+			rho    = (lambda - 0.055*(3+psi)) / lambda / lambda
+			bc     = 1.5 / (1 - psi)
+			aeff   = a - (1-rho)*bc*0.008
+		)
+		if math.Abs(web.K-k) > 1e-9 || math.Abs(web.Rho-rho) > 1e-9 {
+			t.Errorf("web: %#v", web)
+		}
+		if math.Abs(ef.Aeff-aeff) > 1e-9 {
+			t.Errorf("Aeff: %e != %e", ef.Aeff, aeff)
+		}
+		if ef.Forces != f {
+			t.Errorf("not valid forces: %v", ef.Forces)
+		}
+	})
+	t.Run("class 3", func(t *testing.T) {
+		ef, err := section.Effective(section.PlateGroup{Plates: []section.Plate{
+			{Xc: 0, Yc: 0.005, X: 0.100, Y: 0.010},
+			{Xc: 0, Yc: 0.060, X: 0.010, Y: 0.100},
+		}}, section.BendingX, fy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ef.Section.Plates) != 2 || ef.ENx != 0 || ef.ENy != 0 {
+			t.Errorf("section is reduced:\n%s", ef)
+		}
+	})
+	t.Run("validate", func(t *testing.T) {
+		if _, err := section.Effective(girder, section.Compression, 0); err == nil {
+			t.Errorf("zero yield strength")
+		}
+		if _, err := section.Effective(section.PlateGroup{}, section.Compression, fy); err == nil {
+			t.Errorf("section without plates")
+		}
+		if _, err := section.EffectiveForce(girder, section.Forces{}, fy); err == nil {
+			t.Errorf("zero force")
+		}
+	})
+}
+
+func TestMaterial(t *testing.T) {
+	var (
+		steel  = section.Material{Name: "S355", E: 2.1e11, G: 8.1e10, Fy: 355e6, Density: 7850}
+		timber = section.Material{Name: "C24", E: 1.1e10, G: 6.9e8, Fy: 24e6, Density: 420}
+	)
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	t.Run("homogeneous", func(t *testing.T) {
+		is := section.Isections[0]
+		p, err := section.CalculateMaterial(section.MultiMaterial{
+			Regions: []section.Region{{Geor: is, Material: steel}},
+		}, steel.E)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pr, err := section.Calculate(is)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "EA", p.EA, steel.E*pr.A, 1e-9)
+		check(t, "EIxx", p.EIxx, steel.E*pr.AtCenterPoint.Jxx, 1e-9)
+		check(t, "EIyy", p.EIyy, steel.E*pr.AtCenterPoint.Jyy, 1e-9)
+		check(t, "GJ", p.GJ, steel.G*pr.Torsion.It, 1e-6)
+		check(t, "A", p.A, pr.A, 1e-9)
+		check(t, "Mass", p.Mass, steel.Density*pr.A, 1e-9)
+		check(t, "MxPlastic", p.MxPlastic, steel.Fy*pr.AtCenterPoint.WxPlastic, 1e-6)
+		check(t, "MyPlastic", p.MyPlastic, steel.Fy*pr.AtCenterPoint.WyPlastic, 1e-6)
+	})
+	t.Run("flitch beam", func(t *testing.T) {
+		const h, bt, bs = 0.200, 0.050, 0.010
+		m := section.MultiMaterial{Regions: []section.Region{
+			{Geor: section.Translate{Geor: section.Rectangle{H: h, Thk: bt}, X: -(bt + bs) / 2}, Material: timber},
+			{Geor: section.Rectangle{H: h, Thk: bs}, Material: steel},
+			{Geor: section.Translate{Geor: section.Rectangle{H: h, Thk: bt}, X: (bt + bs) / 2}, Material: timber},
+		}}
+		p, err := section.CalculateMaterial(m, timber.E)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "Y", p.Y, h/2, 1e-9)
+		check(t, "EIxx", p.EIxx, (2*timber.E*bt+steel.E*bs)*h*h*h/12, 1e-9)
+		check(t, "Jxx", p.Jxx, (2*bt+steel.E/timber.E*bs)*h*h*h/12, 1e-9)
+		check(t, "MxPlastic", p.MxPlastic, (2*timber.Fy*bt+steel.Fy*bs)*h*h/4, 1e-9)
+		check(t, "NPlastic", p.NPlastic, (2*timber.Fy*bt+steel.Fy*bs)*h, 1e-9)
+		// torsional stiffness between stiffnesses of homogeneous section
+		pr, err := section.Calculate(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !(timber.G*pr.Torsion.It < p.GJ && p.GJ < steel.G*pr.Torsion.It) {
+			t.Errorf("GJ: %e", p.GJ)
+		}
+	})
+	t.Run("plastic neutral axe", func(t *testing.T) {
+		// steel plate below slab
+		m := section.MultiMaterial{Regions: []section.Region{
+			{Geor: section.Rectangle{H: 0.200, Thk: 0.010}, Material: steel},
+			{Geor: section.Translate{Geor: section.Rectangle{H: 0.100, Thk: 0.200}, Y: 0.200}, Material: timber},
+		}}
+		p, err := section.CalculateMaterial(m, steel.E)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			fs = steel.Fy * 0.010
+			fc = timber.Fy * 0.200 * 0.100
+			// depth of plastic neutral axe from top of steel plate
+			x  = ((fc+fs*0.200)/2 - fc) / fs
+			mp = fc*(0.050+x) + fs*x*x/2 + fs*(0.200-x)*(0.200-x)/2
+		)
+		check(t, "YPlastic", p.YPlastic, 0.200-x, 1e-9)
+		check(t, "MxPlastic", p.MxPlastic, mp, 1e-9)
+		if math.Abs(p.XPlastic) > 1e-9 {
+			t.Errorf("XPlastic: %e", p.XPlastic)
+		}
+	})
+	t.Run("validate", func(t *testing.T) {
+		for _, m := range []section.MultiMaterial{
+			{},
+			{Regions: []section.Region{{Geor: section.Rectangle{H: 0.1, Thk: 0.1}}}},
+			{Regions: []section.Region{{Geor: section.Rectangle{H: 0.1, Thk: 0.1}, Material: section.Material{E: 1}}}},
+			// overlapped regions
+			{Regions: []section.Region{
+				{Geor: section.Rectangle{H: 0.1, Thk: 0.1}, Material: steel},
+				{Geor: section.Translate{Geor: section.Rectangle{H: 0.1, Thk: 0.1}, X: 0.05}, Material: steel},
+			}},
+		} {
+			if _, err := section.CalculateMaterial(m, steel.E); err == nil {
+				t.Errorf("not valid section: %v", m)
+			}
+		}
+		if _, err := section.CalculateMaterial(section.MultiMaterial{
+			Regions: []section.Region{{Geor: section.Rectangle{H: 0.1, Thk: 0.1}, Material: steel}},
+		}, 0); err == nil {
+			t.Errorf("zero reference modulus")
+		}
+	})
+}
+
+func TestRC(t *testing.T) {
+	const b, h, d = 0.300, 0.500, 0.450
+	rc := section.RC{
+		Section: section.Rectangle{H: h, Thk: b},
+		Bars: []section.Bar{
+			{X: -0.1, Y: h - d, Diameter: 0.020},
+			{X: 0.0, Y: h - d, Diameter: 0.020},
+			{X: 0.1, Y: h - d, Diameter: 0.020},
+		},
+		Concrete: section.NewConcrete(30e6),
+		Rebar:    section.NewRebar(500e6),
+	}
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	var (
+		as  = 3 * math.Pi * 0.020 * 0.020 / 4
+		fcd = 30e6 / 1.5
+		fyd = 500e6 / 1.15
+	)
+	t.Run("property", func(t *testing.T) {
+		p, err := section.CalculateRC(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := p.Ratio
+		check(t, "Ecm", rc.Concrete.Ecm, 32.837e9, 1e-4)
+		check(t, "As", p.As, as, 1e-9)
+		check(t, "Gross A", p.Gross.A, b*h, 1e-9)
+		check(t, "Gross Jxx", p.Gross.Jxx, b*h*h*h/12, 1e-9)
+		// uncracked
+		var (
+			at = b*h + (n-1)*as
+			yt = (b*h*h/2 + (n-1)*as*(h-d)) / at
+			jt = b*h*h*h/12 + b*h*math.Pow(h/2-yt, 2) + (n-1)*as*math.Pow(yt-(h-d), 2)
+		)
+		check(t, "Uncracked A", p.Uncracked.A, at, 1e-9)
+		check(t, "Uncracked Y", p.Uncracked.Y, yt, 1e-9)
+		check(t, "Uncracked Jxx", p.Uncracked.Jxx, jt, 1e-9)
+		// cracked: b*x^2/2 = n*As*(d-x)
+		var (
+			x  = (-n*as + math.Sqrt(n*n*as*as+2*b*n*as*d)) / b
+			jc = b*x*x*x/3 + n*as*(d-x)*(d-x)
+		)
+		check(t, "Cracked axe", p.CrackedX.Axe, h-x, 1e-9)
+		check(t, "Cracked J", p.CrackedX.J, jc, 1e-6)
+		check(t, "Cracked A", p.CrackedX.A, b*x+n*as, 1e-6)
+		// around axe Y concrete is compressed on full width
+		if !(0 < p.CrackedY.Axe && p.CrackedY.Axe < b/2) {
+			t.Errorf("Cracked axe Y: %e", p.CrackedY.Axe)
+		}
+	})
+	t.Run("interaction", func(t *testing.T) {
+		const angles, steps = 4, 50
+		fs, err := section.InteractionRC(rc, angles, steps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fs) != angles*(3*steps+1) {
+			t.Fatalf("amount of points: %d", len(fs))
+		}
+		// the first point is pure tension, the last is pure compression
+		check(t, "Tension", fs[0].N, -as*fyd, 1e-9)
+		check(t, "Compression", fs[3*steps].N, fcd*(b*h-as)+as*200e9*0.002, 1e-9)
+		// pure bending with compression on top:
+		// 0.8095*fcd*b*x = As*fyd, M = As*fyd*(d-0.416*x)
+		var (
+			x  = as * fyd / (17.0 / 21.0 * fcd * b)
+			mx = as * fyd * (d - 99.0/238.0*x)
+			m  float64
+		)
+		top := fs[3*steps+1 : 2*(3*steps+1)]
+		for i := 1; i < len(top); i++ {
+			a, c := top[i-1], top[i]
+			if a.N <= 0 && 0 < c.N {
+				m = a.Mx + (c.Mx-a.Mx)*(0-a.N)/(c.N-a.N)
+			}
+		}
+		check(t, "Mx", m, mx, 0.005)
+	})
+	t.Run("tsection", func(t *testing.T) {
+		// flange 1000x150 on top of web 300x500, bars 4x40 at depth d
+		const (
+			l, hf = 1.0, 0.15
+			bw    = 0.3
+			d     = 0.6
+		)
+		var (
+			top = hf
+			y   = top - d
+			rc  = section.RC{
+				Section: section.Mirror{Geor: section.Tsection{H: 0.5, Thk: bw, L: l, Thk2: 2 * hf}, ByAxeX: true},
+				Bars: []section.Bar{
+					{X: -0.09, Y: y, Diameter: 0.040},
+					{X: -0.03, Y: y, Diameter: 0.040},
+					{X: +0.03, Y: y, Diameter: 0.040},
+					{X: +0.09, Y: y, Diameter: 0.040},
+				},
+				Concrete: section.NewConcrete(30e6),
+				Rebar:    section.NewRebar(500e6),
+			}
+			as = 4 * math.Pi * 0.040 * 0.040 / 4
+		)
+		p, err := section.CalculateRC(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// cracked axe in web:
+		// l*hf*(x-hf/2) + bw*(x-hf)^2/2 = n*As*(d-x)
+		var (
+			n  = p.Ratio
+			qa = bw / 2
+			qb = l*hf - bw*hf + n*as
+			qc = -l*hf*hf/2 + bw*hf*hf/2 - n*as*d
+			x  = (-qb + math.Sqrt(qb*qb-4*qa*qc)) / (2 * qa)
+			jc = l*hf*hf*hf/12 + l*hf*math.Pow(x-hf/2, 2) + bw*math.Pow(x-hf, 3)/3 +
+				n*as*(d-x)*(d-x)
+		)
+		if x <= hf {
+			t.Fatalf("cracked axe is not in web: %e", x)
+		}
+		check(t, "Cracked axe", p.CrackedX.Axe, top-x, 1e-6)
+		check(t, "Cracked A", p.CrackedX.A, l*hf+bw*(x-hf)+n*as, 1e-6)
+		check(t, "Cracked J", p.CrackedX.J, jc, 1e-6)
+
+		const angles, steps = 4, 50
+		fs, err := section.InteractionRC(rc, angles, steps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "Tension", fs[0].N, -as*fyd, 1e-9)
+		check(t, "Compression", fs[3*steps].N, fcd*(l*hf+bw*0.5-as)+as*200e9*0.002, 1e-9)
+		// pure bending with compression zone in flange:
+		// 0.8095*fcd*l*x = As*fyd, M = As*fyd*(d-0.416*x)
+		var (
+			xp = as * fyd / (17.0 / 21.0 * fcd * l)
+			mx = as * fyd * (d - 99.0/238.0*xp)
+			m  float64
+		)
+		if hf <= xp {
+			t.Fatalf("compression zone is not in flange: %e", xp)
+		}
+		ps := fs[3*steps+1 : 2*(3*steps+1)]
+		for i := 1; i < len(ps); i++ {
+			a, c := ps[i-1], ps[i]
+			if a.N <= 0 && 0 < c.N {
+				m = a.Mx + (c.Mx-a.Mx)*(0-a.N)/(c.N-a.N)
+			}
+		}
+		check(t, "Mx", m, mx, 0.005)
+	})
+	t.Run("validate", func(t *testing.T) {
+		for _, r := range []section.RC{
+			{Bars: rc.Bars, Concrete: rc.Concrete, Rebar: rc.Rebar},
+			{Section: rc.Section, Bars: []section.Bar{{X: 1, Y: 1, Diameter: 0.01}}, Concrete: rc.Concrete, Rebar: rc.Rebar},
+			{Section: rc.Section, Bars: []section.Bar{{X: 0, Y: 0.1}}, Concrete: rc.Concrete, Rebar: rc.Rebar},
+			{Section: rc.Section, Bars: rc.Bars, Rebar: rc.Rebar},
+			{Section: rc.Section, Bars: rc.Bars, Concrete: rc.Concrete},
+		} {
+			if _, err := section.CalculateRC(r); err == nil {
+				t.Errorf("not valid section: %v", r)
+			}
+		}
+		if _, err := section.InteractionRC(rc, 0, 10); err == nil {
+			t.Errorf("zero amount of angles")
+		}
+	})
+}
+
+func TestFibres(t *testing.T) {
+	// sums of fibres
+	sum := func(fs section.FibreSection, tag int) (a, sy, syy, syz float64) {
+		for _, f := range fs.Fibres {
+			if f.Material != tag {
+				continue
+			}
+			a += f.Area
+			sy += f.Area * f.Y
+			syy += f.Area * f.Y * f.Y
+			syz += f.Area * f.Y * f.Z
+		}
+		return
+	}
+	t.Run("centroidal", func(t *testing.T) {
+		r := section.Rectangle{H: 0.2, Thk: 0.1}
+		fs, err := section.Fibres(r, section.FibreOptions{Axes: section.CenterPoint})
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, sy, syy, _ := sum(fs, 0)
+		if math.Abs(a-0.02) > 1e-12 || math.Abs(sy) > 1e-12 ||
+			math.Abs(syy/(0.1*0.008/12)-1) > 0.02 {
+			t.Errorf("not valid fibres: %e %e %e", a, sy, syy)
+		}
+		if math.Abs(fs.Y-0.1) > 1e-12 {
+			t.Errorf("not valid origin: %v", fs.Y)
+		}
+	})
+	t.Run("section axe", func(t *testing.T) {
+		a := section.Angles[0]
+		p, err := section.Calculate(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs, err := section.Fibres(a, section.FibreOptions{Axes: section.SectionAxe})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, syy, syz := sum(fs, 0)
+		if j := p.OnSectionAxe.Jxx; math.Abs(syz) > 0.01*j || math.Abs(syy/j-1) > 0.02 {
+			t.Errorf("not principal axes: %e %e", syy, syz)
+		}
+	})
+	t.Run("lump", func(t *testing.T) {
+		r := section.Rectangle{H: 0.2, Thk: 0.1}
+		fs, err := section.Fibres(r, section.FibreOptions{Axes: section.CenterPoint, MinArea: 0.02 / 4, Material: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, sy, _, _ := sum(fs, 3)
+		if len(fs.Fibres) > 4 || math.Abs(a-0.02) > 1e-12 || math.Abs(sy) > 1e-12 {
+			t.Errorf("not valid lumping: %d %e %e", len(fs.Fibres), a, sy)
+		}
+		for _, f := range fs.Fibres {
+			if f.Area < 0.02/4 {
+				t.Errorf("small fibre: %v", f)
+			}
+		}
+		js, err := fs.JSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := fs.Tcl(1, 0) + fs.Tcl(1, 1e3) + fs.Python(1, 1e3) + string(js)
+		compare.Test(t, td(".fibre"), []byte(out))
+	})
+	t.Run("materials", func(t *testing.T) {
+		var (
+			steel  = section.Material{Name: "S355", E: 2.1e11, G: 8.1e10, Fy: 355e6}
+			timber = section.Material{Name: "C24", E: 1.1e10, G: 6.9e8, Fy: 24e6}
+		)
+		m := section.MultiMaterial{Regions: []section.Region{
+			{Geor: section.Translate{Geor: section.Rectangle{H: 0.2, Thk: 0.05}, X: -0.03}, Material: timber},
+			{Geor: section.Rectangle{H: 0.2, Thk: 0.01}, Material: steel},
+			{Geor: section.Translate{Geor: section.Rectangle{H: 0.2, Thk: 0.05}, X: 0.03}, Material: timber},
+		}}
+		fs, err := section.Fibres(m, section.FibreOptions{Material: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for tag, area := range map[int]float64{10: 0.01, 11: 0.002, 12: 0.01} {
+			if a, _, _, _ := sum(fs, tag); math.Abs(a-area) > 1e-12 {
+				t.Errorf("area of material %d: %e != %e", tag, a, area)
+			}
+		}
+		// origin at elastic center
+		m.Regions = m.Regions[1:]
+		mp, err := section.CalculateMaterial(m, steel.E)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs, err = section.Fibres(m, section.FibreOptions{Axes: section.CenterPoint})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var esz float64
+		for _, f := range fs.Fibres {
+			e := []float64{steel.E, timber.E}[f.Material]
+			esz += e * f.Area * f.Z
+		}
+		if math.Abs(fs.X-mp.X) > 1e-12 || math.Abs(esz) > 1e-9*mp.EA {
+			t.Errorf("not elastic center: %e != %e, %e", fs.X, mp.X, esz)
+		}
+	})
+	t.Run("RC", func(t *testing.T) {
+		rc := section.RC{
+			Section:  section.Rectangle{H: 0.5, Thk: 0.3},
+			Bars:     []section.Bar{{X: -0.1, Y: 0.05, Diameter: 0.02}, {X: 0.1, Y: 0.05, Diameter: 0.02}},
+			Concrete: section.NewConcrete(30e6),
+			Rebar:    section.NewRebar(500e6),
+		}
+		fs, err := section.Fibres(rc, section.FibreOptions{Axes: section.CenterPoint, Material: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a, sy, _, _ := sum(fs, 2); math.Abs(a-2*rc.Bars[0].Area()) > 1e-12 ||
+			math.Abs(sy/a+0.2) > 1e-12 {
+			t.Errorf("not valid bars: %e %e", a, sy)
+		}
+		// concrete displaced by bars
+		if a, _, _, _ := sum(fs, 1); math.Abs(a-(0.15-2*rc.Bars[0].Area())) > 1e-12 {
+			t.Errorf("not valid concrete: %e", a)
+		}
+	})
+	t.Run("validate", func(t *testing.T) {
+		if _, err := section.Fibres(section.Angles[0], section.FibreOptions{Axes: 5}); err == nil {
+			t.Errorf("not valid axes")
+		}
+	})
+}
+
+func TestMomentCurvature(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	const b, h, e, fy, epsu = 0.1, 0.2, 2e11, 235e6, 0.05
+	steel := section.ElasticPlastic{E: e, Fy: fy, Epsu: epsu}
+	rect := section.Nonlinear{Regions: []section.LawRegion{
+		{Geor: section.Rectangle{H: h, Thk: b}, Law: steel},
+	}}
+	t.Run("elastic-plastic", func(t *testing.T) {
+		mc, err := section.CalculateCurvature(rect, 0, 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			ky = fy / e / (h / 2)
+			ku = epsu / (h / 2)
+			mp = fy * b * h * h / 4
+			// moment of rectangle for curvature k
+			m = func(k float64) float64 {
+				if k < ky {
+					return e * b * h * h * h / 12 * k
+				}
+				return mp * (1 - math.Pow(ky/k, 2)/3)
+			}
+		)
+		check(t, "Yield curvature", mc.Yield.Curvature, ky, 1e-6)
+		check(t, "Yield moment", mc.Yield.M, fy*b*h*h/6, 1e-6)
+		check(t, "Ultimate curvature", mc.Ultimate.Curvature, ku, 1e-6)
+		check(t, "Ultimate moment", mc.Ultimate.M, m(ku), 1e-6)
+		if len(mc.Curve) != 11 {
+			t.Fatalf("amount of points: %d", len(mc.Curve))
+		}
+		for i, p := range mc.Curve[1:] {
+			check(t, fmt.Sprintf("Curve %d", i+1), p.M, m(p.Curvature), 1e-6)
+			if 1e-9 < math.Abs(p.Mx-p.M) || 1e-9 < math.Abs(p.My) || 1e-12 < math.Abs(p.Strain) {
+				t.Errorf("not valid point %d: %v", i+1, p)
+			}
+		}
+	})
+	t.Run("axial", func(t *testing.T) {
+		n := 0.5 * fy * b * h
+		mc, err := section.CalculateCurvature(rect, n, 0, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "Strain", mc.Curve[0].Strain, n/(e*b*h), 1e-6)
+		check(t, "Yield moment", mc.Yield.M, (fy-n/(b*h))*b*h*h/6, 1e-6)
+		if mc.Ultimate.M < mc.Yield.M || fy*b*h*h/4 < mc.Ultimate.M {
+			t.Errorf("not valid ultimate moment: %v", mc.Ultimate)
+		}
+		if _, err := section.CalculateCurvature(rect, 1.01*fy*b*h, 0, 4); err == nil {
+			t.Errorf("axial force is more than resistance")
+		}
+	})
+	t.Run("angle", func(t *testing.T) {
+		mc, err := section.CalculateCurvature(rect, 0, math.Pi/2, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// compression of fibres with negative X
+		check(t, "Yield moment", mc.Yield.M, fy*h*b*b/6, 1e-6)
+		check(t, "Yield My", mc.Yield.My, -fy*h*b*b/6, 1e-6)
+		check(t, "Yield curvature", mc.Yield.Curvature, fy/e/(b/2), 1e-6)
+	})
+	t.Run("bilinear", func(t *testing.T) {
+		const et = 0.01 * e
+		s := rect
+		s.Regions = []section.LawRegion{{Geor: rect.Regions[0].Geor,
+			Law: section.Bilinear{E: e, Fy: fy, Et: et, Epsu: epsu}}}
+		mc, err := section.CalculateCurvature(s, 0, 0, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// elastic-plastic part and hardening with elastic law
+		var (
+			ku = epsu / (h / 2)
+			ky = fy / e / (h / 2)
+			mu = fy*b*h*h/4*(1-math.Pow(ky/ku, 2)/3) +
+				et*b*h*h*h/12*ku*(1-3.0/2*ky/ku+math.Pow(ky/ku, 3)/2)
+		)
+		check(t, "Ultimate moment", mc.Ultimate.M, mu, 1e-6)
+	})
+	t.Run("ramberg-osgood", func(t *testing.T) {
+		l := section.RambergOsgood{E: 70e9, Fy: 250e6, N: 20, Epsu: 0.08}
+		for _, s := range []float64{-300e6, -250e6, -1e6, 0, 100e6, 240e6, 280e6} {
+			strain := s/l.E + math.Copysign(0.002*math.Pow(math.Abs(s)/l.Fy, l.N), s)
+			if 1e-6*l.Fy < math.Abs(l.Stress(strain)-s) {
+				t.Errorf("stress %e != %e", l.Stress(strain), s)
+			}
+		}
+		s := rect
+		s.Regions = []section.LawRegion{{Geor: rect.Regions[0].Geor, Law: l}}
+		mc, err := section.CalculateCurvature(s, 0, 0, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 1; i < len(mc.Curve); i++ {
+			if mc.Curve[i].M <= mc.Curve[i-1].M {
+				t.Errorf("moment is not increased at point %d", i)
+			}
+		}
+		// 0.2% proof strain on extreme fibre
+		check(t, "Yield curvature", mc.Yield.Curvature, (l.Fy/l.E+0.002)/(h/2), 1e-6)
+	})
+	t.Run("concrete", func(t *testing.T) {
+		const b, h, d = 0.300, 0.500, 0.450
+		rc := section.RC{
+			Section: section.Rectangle{H: h, Thk: b},
+			Bars: []section.Bar{
+				{X: -0.1, Y: h - d, Diameter: 0.020},
+				{X: 0.0, Y: h - d, Diameter: 0.020},
+				{X: 0.1, Y: h - d, Diameter: 0.020},
+			},
+			Concrete: section.NewConcrete(30e6),
+			Rebar:    section.NewRebar(500e6),
+		}
+		mc, err := section.CalculateCurvature(rc.Nonlinear(), 0, 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// failure by concrete with yielded bars, see TestRC
+		var (
+			as  = 3 * math.Pi * 0.020 * 0.020 / 4
+			fcd = 30e6 / 1.5
+			fyd = 500e6 / 1.15
+			x   = as * fyd / (17.0 / 21.0 * fcd * b)
+			mx  = as * fyd * (d - 99.0/238.0*x)
+		)
+		check(t, "Ultimate curvature", mc.Ultimate.Curvature, 0.0035/x, 0.01)
+		check(t, "Ultimate moment", mc.Ultimate.Mx, mx, 0.005)
+		if mc.Ultimate.Curvature <= mc.Yield.Curvature || mc.Ultimate.M < mc.Yield.M {
+			t.Errorf("not valid yield point: %v", mc.Yield)
+		}
+	})
+	t.Run("validate", func(t *testing.T) {
+		for _, s := range []section.Nonlinear{
+			{},
+			{Regions: []section.LawRegion{{Geor: section.Rectangle{H: h, Thk: b}}}},
+			{Regions: rect.Regions, Bars: []section.LawBar{{Bar: section.Bar{X: 1, Y: 1, Diameter: 0.01}, Law: steel}}},
+			{Regions: rect.Regions, Bars: []section.LawBar{{Bar: section.Bar{Y: 0.1}, Law: steel}}},
+			// overlapped regions
+			{Regions: append(rect.Regions, section.LawRegion{
+				Geor: section.Translate{Geor: section.Rectangle{H: h, Thk: b}, Y: h / 2},
+				Law:  steel,
+			})},
+		} {
+			if _, err := section.CalculateCurvature(s, 0, 0, 4); err == nil {
+				t.Errorf("not valid section: %v", s)
+			}
+		}
+		if _, err := section.CalculateCurvature(rect, 0, 0, 0); err == nil {
+			t.Errorf("zero amount of steps")
+		}
+	})
+}
+
+func TestPlasticInteraction(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect, eps float64) {
+		t.Helper()
+		if eps < math.Abs(actual-expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	const fy = 235e6
+	t.Run("rectangle", func(t *testing.T) {
+		const b, h = 0.1, 0.2
+		var (
+			r  = section.Rectangle{H: h, Thk: b}
+			np = fy * b * h
+			mp = fy * b * h * h / 4
+		)
+		for _, n := range []float64{-1, -0.5, 0, 0.3, 0.9, 1} {
+			pa, err := section.PlasticNeutral(r, fy, n*np, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			check(t, "D", pa.D, -n*h/2, 1e-9)
+			check(t, "N", pa.N, n*np, 1e-6*np)
+			check(t, "Mx", pa.Mx, mp*(1-n*n), 1e-6*mp)
+			check(t, "My", pa.My, 0, 1e-6*mp)
+		}
+		if _, err := section.PlasticNeutral(r, fy, 1.01*np, 0); err == nil {
+			t.Errorf("axial force is more than resistance")
+		}
+	})
+	t.Run("tsection", func(t *testing.T) {
+		var (
+			mp = fy * tsectionWpl
+			// weak axe: flange and web are symmetrical
+			mpy = fy * (0.010*0.200*0.200/4 + 0.190*0.010*0.010/4)
+		)
+		pa, err := section.PlasticNeutral(tsection, fy, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "D", pa.D, tsectionYp-tsectionY, 1e-9)
+		check(t, "Mx", pa.Mx, mp, 1e-6*mp)
+		// compressed fibres with negative X
+		pa, err = section.PlasticNeutral(tsection, fy, 0, math.Pi/2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "D", pa.D, 0, 1e-9)
+		check(t, "My", pa.My, -mpy, 1e-6*mpy)
+		check(t, "Mx", pa.Mx, 0, 1e-6*mpy)
+	})
+	t.Run("surface", func(t *testing.T) {
+		const levels, angles = 4, 8
+		s, err := section.InteractionPlastic(section.Rectangle{H: 0.2, Thk: 0.1}, fy, levels, angles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(s.Contours) != levels+1 {
+			t.Fatalf("amount of contours: %d", len(s.Contours))
+		}
+		for i, c := range s.Contours {
+			if len(c) != angles {
+				t.Fatalf("amount of points: %d", len(c))
+			}
+			n := s.NPlastic * (2*float64(i)/levels - 1)
+			for _, pa := range c {
+				check(t, "N", pa.N, n, 1e-6*s.NPlastic)
+			}
+		}
+		// opposite angles
+		for _, c := range s.Contours {
+			for k := 0; k < angles/2; k++ {
+				a, b := c[k], c[k+angles/2]
+				check(t, "Mx", a.Mx, -b.Mx, 1e-6*s.NPlastic)
+				check(t, "My", a.My, -b.My, 1e-6*s.NPlastic)
+			}
+		}
+		if _, err := section.InteractionPlastic(section.Rectangle{H: 0.2, Thk: 0.1}, fy, 0, angles); err == nil {
+			t.Errorf("zero amount of levels")
+		}
+		if _, err := section.InteractionPlastic(section.Rectangle{H: 0.2, Thk: 0.1}, 0, levels, angles); err == nil {
+			t.Errorf("zero yield strength")
+		}
+	})
+}
+
+func TestPlasticNeutral(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect float64) {
+		t.Helper()
+		if 1e-6 < math.Abs((actual-expect)/expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	t.Run("polygons", func(t *testing.T) {
+		pr, err := section.Calculate(tsection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, "YPlasticNeutral", pr.AtCenterPoint.YPlasticNeutral, tsectionYp-tsectionY)
+		check(t, "YPlasticNeutral at base point", pr.AtBasePoint.YPlasticNeutral, tsectionYp)
+		check(t, "WxPlastic", pr.AtCenterPoint.WxPlastic, tsectionWpl)
+		check(t, "WxPlastic at base point", pr.AtBasePoint.WxPlastic, tsectionWpl)
+		if 1e-9 < math.Abs(pr.AtCenterPoint.XPlasticNeutral) {
+			t.Errorf("XPlasticNeutral of symmetrical section: %e", pr.AtCenterPoint.XPlasticNeutral)
+		}
+	})
+	t.Run("mesh", func(t *testing.T) {
+		mesh, err := section.GenerateMsh(tsection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes := append([]msh.Node{}, mesh.Nodes...)
+		y := section.YPlasticNeutral(*mesh)
+		check(t, "YPlasticNeutral", y, tsectionYp)
+		for i := range nodes {
+			if nodes[i] != mesh.Nodes[i] {
+				t.Fatalf("mesh is changed: %v != %v", nodes[i], mesh.Nodes[i])
+			}
+		}
+		section.MoveXOY(mesh, 0, -y)
+		check(t, "WxPlastic", section.WxPlastic(*mesh), tsectionWpl)
+	})
+}
+
+func TestExtremeFibres(t *testing.T) {
+	check := func(t *testing.T, name string, actual, expect float64) {
+		t.Helper()
+		if 1e-6 < math.Abs(actual-expect) {
+			t.Errorf("%s: %e != %e", name, actual, expect)
+		}
+	}
+	t.Run("tsection", func(t *testing.T) {
+		pr, err := section.Calculate(tsection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := pr.AtCenterPoint
+		check(t, "YTop", b.YTop, 0.2-pr.Y)
+		check(t, "YBottom", b.YBottom, pr.Y)
+		check(t, "XRight", b.XRight, 0.1)
+		check(t, "XLeft", b.XLeft, 0.1)
+		check(t, "WxTop", b.WxTop*1e6, b.Jxx/(0.2-pr.Y)*1e6)
+		check(t, "WxBottom", b.WxBottom*1e6, b.Jxx/pr.Y*1e6)
+		check(t, "Wx", b.Wx*1e6, math.Min(b.WxTop, b.WxBottom)*1e6)
+		// base point is on bottom fibre
+		check(t, "YBottom at base point", pr.AtBasePoint.YBottom, 0)
+		check(t, "WxBottom at base point", pr.AtBasePoint.WxBottom, 0)
+		// mesh
+		mesh, err := section.GenerateMsh(tsection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		section.MoveXOY(mesh, 0, -pr.Y)
+		top, bottom := section.Yextreme(*mesh)
+		check(t, "Yextreme top", top, b.YTop)
+		check(t, "Yextreme bottom", bottom, b.YBottom)
+	})
+	t.Run("angle", func(t *testing.T) {
+		pr, err := section.Calculate(section.UnequalAngle{Width1: 0.15, Width2: 0.1, Thk: 0.01})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, b := range []section.BendingProperty{pr.AtCenterPoint, pr.OnSectionAxe} {
+			check(t, "Wx", b.Wx*1e6, math.Min(b.WxTop, b.WxBottom)*1e6)
+			check(t, "Wy", b.Wy*1e6, math.Min(b.WyRight, b.WyLeft)*1e6)
+			check(t, "Ymax", b.Ymax, math.Max(b.YTop, b.YBottom))
+			check(t, "Xmax", b.Xmax, math.Max(b.XRight, b.XLeft))
+		}
+		p := pr.Principal
+		check(t, "Wu", p.Wu*1e6, math.Min(p.WuTop, p.WuBottom)*1e6)
+		check(t, "Wv", p.Wv*1e6, math.Min(p.WvRight, p.WvLeft)*1e6)
+		check(t, "WuTop", p.WuTop*1e6, p.Ju/p.Top.V*1e6)
+		check(t, "WvLeft", p.WvLeft*1e6, -p.Jv/p.Left.U*1e6)
+	})
+}
+
+func Test(t *testing.T) {
+	t.Run("channel", func(t *testing.T) {
+		name := "Швеллер 20У ГОСТ 8240"
+		c20, err := section.Get(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pr, err := section.Calculate(c20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := printJson(pr)
+		compare.Test(t, td(".test"), []byte(s))
+
+		c20pr, err := section.GetProperty(c20)
+		s2 := printJson(&c20pr)
+
+		if s != s2 {
+			t.Fatalf("diff names")
+		}
+	})
+	t.Run("list", func(t *testing.T) {
+		list := section.GetList()
+		var buf bytes.Buffer
+		for i := range list {
+			fmt.Fprintf(&buf, "%s\n", list[i].GetName())
+		}
+		compare.Test(t, td(".test.list"), buf.Bytes())
+	})
+	t.Run("plate group", func(t *testing.T) {
+		pg := section.PlateGroup{
+			Name: "test",
+			Plates: []section.Plate{
+				{Xc: 0.0000, Yc: 0.0175, X: 0.3000, Y: 0.0350},
+				{Xc: 0.0000, Yc: 0.8825, X: 0.3000, Y: 0.0350},
+				{Xc: 0.0000, Yc: 0.4500, X: 0.0185, Y: 0.8300},
+			},
+		}
+		pr, err := section.Calculate(pg)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+		compare.Test(t, "test.plate.group", []byte(pr.String()))
+	})
+}
+
+// cpu: Intel(R) Xeon(R) CPU E3-1240 V2 @ 3.40GHz
+// Benchmark/Get-4         	 5738064	        212.8 ns/op	      64 B/op	       1 allocs/op
+// Benchmark/Calculate-4   	       4	    284656617 ns/op	 1186370 B/op	   10855 allocs/op
+// Benchmark/GetProperty-4 	37749807	        31.03 ns/op	       0 B/op	       0 allocs/op
+//
+// cpu: Intel(R) Xeon(R) CPU           X5550  @ 2.67GHz
+// Benchmark/Get-8         	   75926	     18192 ns/op	    9520 B/op	      98 allocs/op
+// Benchmark/Calculate-8   	       4	 286957623 ns/op	 1276140 B/op	   10995 allocs/op
+// Benchmark/GetProperty-8 	13540945	        79.70 ns/op	       0 B/op	       0 allocs/op
+//
+// Benchmark/Get/0-8       	   69296	     18588 ns/op	    9520 B/op	      98 allocs/op
+// Benchmark/Get/1-8       	   63228	     18566 ns/op	    9520 B/op	      98 allocs/op
+// Benchmark/Calculate/0-8 	       4	 286663606 ns/op	 1274800 B/op	   10997 allocs/op
+// Benchmark/Calculate/1-8 	       7	 150331238 ns/op	  157937 B/op	     664 allocs/op
+// Benchmark/Property/0-8  	13376570	        78.90 ns/op	       0 B/op	       0 allocs/op
+// Benchmark/Property/1-8  	14337594	        81.01 ns/op	       0 B/op	       0 allocs/op
+//
+// Native mesher, without gmsh:
+// cpu: Intel(R) Xeon(R) Processor
+// Benchmark/Get/0       	   52758	     24007 ns/op	   21280 B/op	     215 allocs/op
+// Benchmark/Get/1       	   53490	     21648 ns/op	   21280 B/op	     215 allocs/op
+// Benchmark/Calculate/0 	       3	 477342757 ns/op	37006581 B/op	  212298 allocs/op
+// Benchmark/Calculate/1 	      26	  43966412 ns/op	 6712864 B/op	   50828 allocs/op
+// Benchmark/Property/0  	13330926	        89.86 ns/op	       0 B/op	       0 allocs/op
+// Benchmark/Property/1  	13454173	        91.77 ns/op	       0 B/op	       0 allocs/op
 func Benchmark(b *testing.B) {
 	names := []string{"Швеллер 20У ГОСТ 8240", "Plate 100x10"}
 	b.Run("Get", func(b *testing.B) {
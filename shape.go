@@ -55,25 +55,23 @@ type BendingProperty struct {
 	Jyy, Xmax, Wy, Ry, Sy, WyPlastic float64 // bending moments of inertia
 	Jxy                              float64 // centrifugal moment of inertia
 	Jo, Ro                           float64 // polar moment of inertia
-
-	// TODO
-	// Sx, Sy float64 // first moment of area
-	// TODO : tau_xz = (Vy * Qz) / (Jz * t)
-	// TODO : shear_area = (Jz * t) / Qz is maximal on each sections
-	// Example http://www.learneasy.info/MDME/MEMmods/MEM09155A-CAE/050-Shear-in-Bending/shear-in-bending.html:
-	// I-section
-	//		height = 150
-	//		width  = 100
-	//		tw     = 10
-	//		tf     = 10
-	// 	J = 1.16e7 mm4
-	// section A-A on 30 mm from neutral axe
-	// if V = 25kN, then Q = 86625 mm3 and tau = 18.6MPa
-	//
-	// section B-B on 70 mm from neutral axe
-	// in flange b = 100 mm, tau = 1.5 MPa
-	// in web b = 10 mm, tau = 15 MPa
-	// TODO: check on circle, ring, T-section
+	Xs, Ys                           float64 // location of shear center
+	Avx, Avy                         float64 // shear areas
+
+	// Distances from axes to extreme fibres and elastic moment
+	// resistances for these fibres. Top and bottom fibres have maximal
+	// and minimal Y, right and left fibres have maximal and minimal X.
+	// Distances to bottom and left fibres are -Ymin and -Xmin, resistance
+	// is zero for not positive distance.
+	YTop, YBottom, WxTop, WxBottom float64
+	XRight, XLeft, WyRight, WyLeft float64
+
+	// Location of plastic neutral axes with equal areas on both sides,
+	// offset from center of section for axes at center point. Plastic
+	// moment resistances are calculated around these axes.
+	YPlasticNeutral, XPlasticNeutral float64
+
+	// Shear stress on the cut line, see ShearStress
 	// See https://engineering.stackexchange.com/questions/7989/shear-area-of-atypical-section
 	// TODO https://www.ae.msstate.edu/tupas/SA2/Course.html
 }
@@ -86,15 +84,25 @@ func (b BendingProperty) String() string {
 	fmt.Fprintf(w, "Jxx\t%s\tMoment inertia by axe X\n", efmt.Sprint(b.Jxx))
 	fmt.Fprintf(w, "Ymax\t%s\tMaximal distance from axe X\n", efmt.Sprint(b.Ymax))
 	fmt.Fprintf(w, "Wx\t%s\tElastic moment resistance around axe X\n", efmt.Sprint(b.Wx))
+	fmt.Fprintf(w, "YTop\t%s\tDistance from axe X to top fibre\n", efmt.Sprint(b.YTop))
+	fmt.Fprintf(w, "WxTop\t%s\tElastic moment resistance around axe X for top fibre\n", efmt.Sprint(b.WxTop))
+	fmt.Fprintf(w, "YBottom\t%s\tDistance from axe X to bottom fibre\n", efmt.Sprint(b.YBottom))
+	fmt.Fprintf(w, "WxBottom\t%s\tElastic moment resistance around axe X for bottom fibre\n", efmt.Sprint(b.WxBottom))
 	fmt.Fprintf(w, "Rx\t%s\tRadius inertia around axe X\n", efmt.Sprint(b.Rx))
 	fmt.Fprintf(w, "WxPlastic\t%s\tPlastic moment resistance around axe X\n", efmt.Sprint(b.WxPlastic))
+	fmt.Fprintf(w, "YPlasticNeutral\t%s\tLocation of plastic neutral axe by axe Y\n", efmt.Sprint(b.YPlasticNeutral))
 	// by axe Y
 	fmt.Fprintf(w, "By axe\tY\t.\n")
 	fmt.Fprintf(w, "Jyy\t%s\tMoment inertia by axe Y\n", efmt.Sprint(b.Jyy))
 	fmt.Fprintf(w, "Xmax\t%s\tMaximal distance from axe Y\n", efmt.Sprint(b.Xmax))
 	fmt.Fprintf(w, "Wy\t%s\tElastic moment resistance around axe Y\n", efmt.Sprint(b.Wy))
+	fmt.Fprintf(w, "XRight\t%s\tDistance from axe Y to right fibre\n", efmt.Sprint(b.XRight))
+	fmt.Fprintf(w, "WyRight\t%s\tElastic moment resistance around axe Y for right fibre\n", efmt.Sprint(b.WyRight))
+	fmt.Fprintf(w, "XLeft\t%s\tDistance from axe Y to left fibre\n", efmt.Sprint(b.XLeft))
+	fmt.Fprintf(w, "WyLeft\t%s\tElastic moment resistance around axe Y for left fibre\n", efmt.Sprint(b.WyLeft))
 	fmt.Fprintf(w, "Ry\t%s\tRadius inertia around axe Y\n", efmt.Sprint(b.Ry))
 	fmt.Fprintf(w, "WyPlastic\t%s\tPlastic moment resistance around axe Y\n", efmt.Sprint(b.WyPlastic))
+	fmt.Fprintf(w, "XPlasticNeutral\t%s\tLocation of plastic neutral axe by axe X\n", efmt.Sprint(b.XPlasticNeutral))
 	// other
 	fmt.Fprintf(w, "By axe\tOther\t.\n")
 	fmt.Fprintf(w, "Jxy\t%s\tCentrifugal moment inertia by axe X-Y\n", efmt.Sprint(b.Jxy))
@@ -102,38 +110,66 @@ func (b BendingProperty) String() string {
 	fmt.Fprintf(w, "By axe\tPolar\t.\n")
 	fmt.Fprintf(w, "Jo\t%s\tPolar moment inertia\n", efmt.Sprint(b.Jo))
 	fmt.Fprintf(w, "Ro\t%s\tPolar radius moment inertia\n", efmt.Sprint(b.Ro))
+	// shear center
+	fmt.Fprintf(w, "By axe\tShear center\t.\n")
+	fmt.Fprintf(w, "Xs\t%s\tLocation of shear center by axe X\n", efmt.Sprint(b.Xs))
+	fmt.Fprintf(w, "Ys\t%s\tLocation of shear center by axe Y\n", efmt.Sprint(b.Ys))
+	// shear areas
+	fmt.Fprintf(w, "By axe\tShear\t.\n")
+	fmt.Fprintf(w, "Avx\t%s\tShear area for shear force by axe X\n", efmt.Sprint(b.Avx))
+	fmt.Fprintf(w, "Avy\t%s\tShear area for shear force by axe Y\n", efmt.Sprint(b.Avy))
 
 	fmt.Fprintf(w, "\n")
 	w.Flush()
 	return buf.String()
 }
 
+// fibres calculate elastic moment resistances for extreme fibres.
+// Resistance is zero for fibre on axe or on other side of axe.
+func (b *BendingProperty) fibres() {
+	b.WxTop, b.WxBottom = modulus(b.Jxx, b.YTop), modulus(b.Jxx, b.YBottom)
+	b.WyRight, b.WyLeft = modulus(b.Jyy, b.XRight), modulus(b.Jyy, b.XLeft)
+}
+
+// modulus return section modulus for moment of inertia `j` and
+// distance `d` to fibre. Fibre on axe or behind it has no modulus.
+func modulus(j, d float64) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return j / d
+}
+
 // In principal axes, that are rotated by an angle θ relative
 // to original centroidal ones x,y, the product of inertia becomes zero.
 func (b *BendingProperty) Alpha() float64 {
-	angle := math.Atan(-2.0*b.Jxy/(b.Jxx-b.Jyy)) / 2.0
+	angle := math.Atan2(-2.0*b.Jxy, b.Jxx-b.Jyy) / 2.0
 	angle += math.Pi / 2.0
 	return angle
 }
 
 func (b *BendingProperty) Calculate(mesh msh.Msh) {
 	A, _ := Area(mesh)
-	calc := func() (j, h, w, r, wpl, s float64) {
+	calc := func() (j, h, w, r, wpl, s, yp float64) {
 		j = Jxx(mesh)
 		h = Ymax(mesh)
 		w = j / h
 		r = math.Sqrt(j / A)
-		wpl = WxPlastic(mesh)
+		yp = YPlasticNeutral(mesh)
+		wpl = wxPlastic(mesh, yp)
 		s = Sx(mesh)
 		return
 	}
 
 	const perp float64 = math.Pi / 2.0 // 90 degree
 
-	b.Jxx, b.Ymax, b.Wx, b.Rx, b.WxPlastic, b.Sx = calc()
+	b.Jxx, b.Ymax, b.Wx, b.Rx, b.WxPlastic, b.Sx, b.YPlasticNeutral = calc()
+	b.YTop, b.YBottom = Yextreme(mesh)
 	RotateXOY(&mesh, perp)
-	b.Jyy, b.Xmax, b.Wy, b.Ry, b.WyPlastic, b.Sy = calc()
+	b.Jyy, b.Xmax, b.Wy, b.Ry, b.WyPlastic, b.Sy, b.XPlasticNeutral = calc()
+	b.XRight, b.XLeft = Yextreme(mesh)
 	RotateXOY(&mesh, -perp)
+	b.fibres()
 	b.Jxy = Jxy(mesh)
 	b.Jo = b.Jxx + b.Jyy
 	b.Ro = math.Sqrt(b.Jo / A)
@@ -158,11 +194,13 @@ type Property struct {
 	//	* maximal moment inertia on axe y
 	OnSectionAxe BendingProperty
 
-	// TODO: torsion property
-	// TODO: shear area
+	// Property on principal axes U-V
+	Principal PrincipalProperty
+
+	// Saint-Venant torsion property
+	Torsion TorsionProperty
+
 	// TODO: polar moment inertia
-	// TODO: check on local buckling
-	// TODO: center of shear
 }
 
 func (p Property) GetName() string {
@@ -185,6 +223,8 @@ func (p Property) String() string {
 	fmt.Fprintf(w, "Bending property: At base point\n%s", p.AtBasePoint)
 	fmt.Fprintf(w, "Bending property: At center point\n%s", p.AtCenterPoint)
 	fmt.Fprintf(w, "Bending property: On section axe\n%s", p.OnSectionAxe)
+	fmt.Fprintf(w, "Principal axes U-V\n%s", p.Principal)
+	fmt.Fprintf(w, "Torsion property\n%s", p.Torsion)
 	fmt.Fprintf(w, "\n")
 	w.Flush()
 	return buf.String()
@@ -276,7 +316,31 @@ func J(b, h float64) float64 {
 	return math.Abs(b * pow.E3(h) / 12.0)
 }
 
+// Gmsh is section with mesh generated by gmsh from .geo file.
+// Gmsh executable is necessary.
+//
+// Example:
+//
+//	Calculate(Gmsh{Isections[0]})
+type Gmsh struct {
+	Geor
+}
+
+// GenerateMsh return triangle mesh of section. Sections with contours,
+// see Polygoner and Bounder, are meshed without gmsh.
 func GenerateMsh(g Geor) (mesh *msh.Msh, err error) {
+	rings, err := contours(g)
+	if err != nil {
+		return
+	}
+	if rings != nil {
+		return triangulate(rings, meshSize(rings))
+	}
+	return generateGeo(g)
+}
+
+// generateGeo return mesh by gmsh
+func generateGeo(g Geor) (mesh *msh.Msh, err error) {
 	// calculate area and choose precition
 	newArea, lastArea := 0.0, 0.0
 	var prec float64 = 0.1 // TODO: auto finding
@@ -312,29 +376,105 @@ func GenerateMsh(g Geor) (mesh *msh.Msh, err error) {
 }
 
 func Calculate(g Geor) (p *Property, err error) {
+	p, _, err = CalculateWithMesh(g, BasePoint)
+	return
+}
+
+// Axes is coordinate system of section
+type Axes int
+
+const (
+	BasePoint   Axes = iota // coordinates of shape
+	CenterPoint             // origin at the center of section
+	SectionAxe              // origin at the center of section, rotated on angle Alpha
+)
+
+// CalculateWithMesh return property of section and converged mesh in
+// coordinate system `axes`.
+func CalculateWithMesh(g Geor, axes Axes) (p *Property, mesh *msh.Msh, err error) {
+	if axes != BasePoint && axes != CenterPoint && axes != SectionAxe {
+		err = fmt.Errorf("not valid axes: %d", axes)
+		return
+	}
+	defer func() {
+		if err != nil {
+			p, mesh = nil, nil
+		}
+	}()
+	rings, err := contours(g)
+	if err != nil {
+		return
+	}
+	if rings != nil {
+		// bending property by contours, mesh is only for torsion and shear
+		p = calculatePolygons(g.GetName(), rings)
+	}
 	// find acceptable mesh
-	mesh, err := GenerateMsh(g)
+	mesh, err = GenerateMsh(g)
 	if err != nil {
 		return
 	}
-	var center msh.Node
-	p = new(Property)
-	p.Name = g.GetName()
-	p.A, center = Area(*mesh)
-	p.X = center.Coord[0]
-	p.Y = center.Coord[1]
-	// calculate at the base point
-	p.AtBasePoint.Calculate(*mesh)
+	var result *msh.Msh
+	keep := func(a Axes) {
+		if a == axes {
+			m := mesh.Clone()
+			result = &m
+		}
+	}
+	keep(BasePoint)
+	if p == nil {
+		var center msh.Node
+		p = new(Property)
+		p.Name = g.GetName()
+		p.A, center = Area(*mesh)
+		p.X = center.Coord[0]
+		p.Y = center.Coord[1]
+		// calculate at the base point
+		p.AtBasePoint.Calculate(*mesh)
+	}
 	// calculate at the center point
 	MoveXOY(mesh, -p.X, -p.Y)
-	// calculate at the center point
-	p.AtCenterPoint.Calculate(*mesh)
+	keep(CenterPoint)
+	if rings == nil {
+		p.AtCenterPoint.Calculate(*mesh)
+		p.Principal.Calculate(*mesh, p.AtCenterPoint, p.A, p.X, p.Y)
+	}
+	// torsion property
+	ss := newStressSolution(*mesh)
+	if err = ss.solve(); err != nil {
+		return
+	}
+	xs, ys, err := p.Torsion.calculate(ss.mesh, ss.ts, ss.w)
+	if err != nil {
+		return
+	}
+	// shear areas
+	p.AtCenterPoint.Avx, p.AtCenterPoint.Avy, err = ss.sf.areas(0)
+	if err != nil {
+		return
+	}
+	p.AtBasePoint.Avx, p.AtBasePoint.Avy = p.AtCenterPoint.Avx, p.AtCenterPoint.Avy
 	// calculate at the center point with Jx minimal moment of inertia
-	p.Alpha = p.AtCenterPoint.Alpha()
+	if rings == nil {
+		p.Alpha = p.AtCenterPoint.Alpha()
+	}
 	// rotate
 	RotateXOY(mesh, -p.Alpha)
+	keep(SectionAxe)
 	// calculate at the center point and rotate axes
-	p.OnSectionAxe.Calculate(*mesh)
+	if rings == nil {
+		p.OnSectionAxe.Calculate(*mesh)
+	}
+	p.OnSectionAxe.Avx, p.OnSectionAxe.Avy, err = ss.sf.areas(p.Alpha)
+	if err != nil {
+		return
+	}
+	// location of shear center
+	p.AtBasePoint.Xs, p.AtBasePoint.Ys = xs+p.X, ys+p.Y
+	p.AtCenterPoint.Xs, p.AtCenterPoint.Ys = xs, ys
+	p.OnSectionAxe.Xs = xs*math.Cos(-p.Alpha) - ys*math.Sin(-p.Alpha)
+	p.OnSectionAxe.Ys = xs*math.Sin(-p.Alpha) + ys*math.Cos(-p.Alpha)
+	mesh = result
 	return
 }
 
@@ -389,6 +529,21 @@ func Ymax(mesh msh.Msh) float64 {
 	return yMax
 }
 
+// Yextreme return distances from axe X to fibres with maximal and
+// minimal Y
+func Yextreme(mesh msh.Msh) (top, bottom float64) {
+	for i := range mesh.Nodes {
+		y := mesh.Nodes[i].Coord[1]
+		if i == 0 || top < y {
+			top = y
+		}
+		if i == 0 || y < -bottom {
+			bottom = -y
+		}
+	}
+	return
+}
+
 // first moment of area
 // Sx = integral{y, dA)
 func Sx(mesh msh.Msh) float64 {
@@ -463,10 +618,7 @@ func Jxy(mesh msh.Msh) float64 {
 
 		J += jxy
 	}
-	if J < 0 {
-		J = 0.0
-	}
-	return 1.0 / 24.0 * J
+	return -1.0 / 24.0 * J
 }
 
 // To find orientation of ordered triplet (p1, p2, p3).
@@ -492,7 +644,48 @@ func OnAxeX(a, b msh.Node) (c msh.Node) {
 	return
 }
 
+// WxPlastic return plastic section modulus around axe X
 func WxPlastic(mesh msh.Msh) (w float64) {
+	return wxPlastic(mesh, 0)
+}
+
+// wxPlastic return plastic section modulus around axe parallel to axe X
+// on location y
+func wxPlastic(mesh msh.Msh, y float64) (w float64) {
+	splitAxeX(mesh, y, func(area float64, center msh.Node) {
+		w += area * math.Abs(center.Coord[1])
+	})
+	return
+}
+
+// YPlasticNeutral return location of plastic neutral axe parallel to
+// axe X with equal areas above and below. Location is found by bisection
+// on the mesh.
+func YPlasticNeutral(mesh msh.Msh) (y float64) {
+	var (
+		half, _ = Area(mesh)
+		ymin    = math.Inf(1)
+		ymax    = math.Inf(-1)
+	)
+	half /= 2
+	for _, n := range mesh.Nodes {
+		ymin = math.Min(ymin, n.Coord[1])
+		ymax = math.Max(ymax, n.Coord[1])
+	}
+	return neutral(ymin, ymax, half, func(y float64) (up float64) {
+		splitAxeX(mesh, y, func(area float64, center msh.Node) {
+			if 0 < center.Coord[1] {
+				up += area
+			}
+		})
+		return
+	})
+}
+
+// splitAxeX split triangles of mesh by axe parallel to axe X on location
+// y and call f for each part. Coordinates of parts are relative to axe.
+// Mesh is not changed.
+func splitAxeX(mesh msh.Msh, y float64, f func(area float64, center msh.Node)) {
 	for i := range mesh.Elements {
 		if mesh.Elements[i].EType != msh.Triangle {
 			continue
@@ -505,6 +698,9 @@ func WxPlastic(mesh msh.Msh) (w float64) {
 			}
 			sign [3]bool
 		)
+		for i := range p {
+			p[i].Coord[1] -= y
+		}
 		p[0], p[1], p[2] = SortByY(p[0], p[1], p[2])
 		for i := range p {
 			sign[i] = math.Signbit(p[i].Coord[1])
@@ -542,10 +738,7 @@ func WxPlastic(mesh msh.Msh) (w float64) {
 		}
 		// calculate for one triangle
 		for _, n := range tr {
-			area := Area3node(n[0], n[1], n[2])
-			center := Center3node(n[0], n[1], n[2])
-			w += area * math.Abs(center.Coord[1])
+			f(Area3node(n[0], n[1], n[2]), Center3node(n[0], n[1], n[2]))
 		}
 	}
-	return
 }
@@ -0,0 +1,200 @@
+package section
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Konstantin8105/msh"
+)
+
+// Shear functions for transverse shear forces Vx, Vy (Poisson ratio is zero):
+//
+//	div(grad(Psi)) = 2*(Jxx*x - Jxy*y)
+//	div(grad(Phi)) = 2*(Jyy*y - Jxy*x)
+//	dPsi/dn = 0, dPhi/dn = 0 on contour of section
+//
+//	Delta = 2*(Jxx*Jyy - Jxy^2)
+//
+//	Shear stresses:
+//	tau_zx, tau_zy = Vx/Delta * grad(Psi) + Vy/Delta * grad(Phi)
+//
+//	Shear areas:
+//	Avx = Delta^2 / integral(grad(Psi)*grad(Psi),dA)
+//	Avy = Delta^2 / integral(grad(Phi)*grad(Phi),dA)
+//
+// Coordinates of mesh must be located at the center of section.
+//
+// See "Analysis and Design of Elastic Beams", Walter D. Pilkey, 2002.
+type shearFunction struct {
+	ts       []triangle
+	psi, phi []float64
+	delta    float64
+}
+
+func newShearFunction(mesh msh.Msh) (sf *shearFunction, err error) {
+	sf = new(shearFunction)
+	sf.ts = triangles(mesh)
+	var jxx, jyy, jxy float64
+	for _, t := range sf.ts {
+		jxx += t.integral(t.y, t.y)
+		jyy += t.integral(t.x, t.x)
+		jxy += t.integral(t.x, t.y)
+	}
+	sf.delta = 2 * (jxx*jyy - jxy*jxy)
+	if sf.delta <= 0 {
+		err = fmt.Errorf("not valid moment of inertia")
+		return
+	}
+	k, fix := laplace(mesh, sf.ts)
+	var (
+		fpsi = make([]float64, len(mesh.Nodes))
+		fphi = make([]float64, len(mesh.Nodes))
+	)
+	for _, t := range sf.ts {
+		for i := range t.index {
+			var n [3]float64
+			n[i] = 1.0
+			fpsi[t.index[i]] += 2 * (jxx*t.integral(n, t.x) - jxy*t.integral(n, t.y))
+			fphi[t.index[i]] += 2 * (jyy*t.integral(n, t.y) - jxy*t.integral(n, t.x))
+		}
+	}
+	for _, n := range fix {
+		fpsi[n], fphi[n] = 0, 0
+	}
+	if sf.psi, err = k.solve(fpsi); err != nil {
+		err = fmt.Errorf("shear function: %v", err)
+		return
+	}
+	if sf.phi, err = k.solve(fphi); err != nil {
+		err = fmt.Errorf("shear function: %v", err)
+		return
+	}
+	return
+}
+
+// ShearArea return shear areas for shear forces by axes X and Y.
+// Coordinates of mesh must be located at the center of section.
+func ShearArea(mesh msh.Msh) (Avx, Avy float64, err error) {
+	sf, err := newShearFunction(mesh)
+	if err != nil {
+		return
+	}
+	return sf.areas(0)
+}
+
+// areas return shear areas by shear functions for axes rotated on
+// angle `a`. Shear functions of rotated axes are
+//
+//	Psi' =  cos(a)*Psi + sin(a)*Phi
+//	Phi' = -sin(a)*Psi + cos(a)*Phi
+//
+// because right parts of equations are rotated as vector and Delta is
+// not changed.
+func (sf *shearFunction) areas(a float64) (Avx, Avy float64, err error) {
+	var kpp, kff, kpf float64
+	for _, t := range sf.ts {
+		psix, psiy := t.gradient(sf.psi)
+		phix, phiy := t.gradient(sf.phi)
+		kpp += t.area * (psix*psix + psiy*psiy)
+		kff += t.area * (phix*phix + phiy*phiy)
+		kpf += t.area * (psix*phix + psiy*phiy)
+	}
+	var (
+		c, s = math.Cos(a), math.Sin(a)
+		kx   = c*c*kpp + 2*c*s*kpf + s*s*kff
+		ky   = s*s*kpp - 2*c*s*kpf + c*c*kff
+	)
+	if kx <= 0 || ky <= 0 {
+		err = fmt.Errorf("not valid shear functions")
+		return
+	}
+	Avx = sf.delta * sf.delta / kx
+	Avy = sf.delta * sf.delta / ky
+	return
+}
+
+// ShearCut is shear stress on the cut line parallel axe X by formula:
+//
+//	tau = V * Q / (Jxx * t)
+//
+// where Q is first moment of area of part above the cut line.
+type ShearCut struct {
+	Y   float64 // location of cut line
+	T   float64 // width of section on cut line
+	Q   float64 // first moment of area above the cut line
+	Tau float64 // average shear stress on cut line
+}
+
+// ShearStress return shear stress on the cut line y = const for shear
+// force V by axe Y. Coordinates of mesh must be located at the center
+// of section.
+func ShearStress(mesh msh.Msh, V, y float64) (sc ShearCut) {
+	sc.Y = y
+	var j float64
+	for _, t := range triangles(mesh) {
+		j += t.integral(t.y, t.y)
+		_, s := t.above(y)
+		sc.Q += s
+		sc.T += t.width(y)
+	}
+	if sc.T == 0 || j == 0 {
+		return
+	}
+	sc.Tau = V * sc.Q / (j * sc.T)
+	return
+}
+
+// above return area and first moment of area integral(y,dA) for part of
+// triangle above the line y = c
+func (t triangle) above(c float64) (area, s float64) {
+	var xs, ys []float64
+	for i := range t.index {
+		j := (i + 1) % 3
+		if c <= t.y[i] {
+			xs = append(xs, t.x[i])
+			ys = append(ys, t.y[i])
+		}
+		if (t.y[i] < c && c < t.y[j]) || (t.y[j] < c && c < t.y[i]) {
+			xs = append(xs, t.x[i]+(t.x[j]-t.x[i])*(c-t.y[i])/(t.y[j]-t.y[i]))
+			ys = append(ys, c)
+		}
+	}
+	// polygon formulas
+	for i := range xs {
+		j := (i + 1) % len(xs)
+		cross := xs[i]*ys[j] - xs[j]*ys[i]
+		area += cross / 2.0
+		s += (ys[i] + ys[j]) * cross / 6.0
+	}
+	if area < 0 {
+		area, s = -area, -s
+	}
+	return
+}
+
+// width return length of intersection triangle with line y = c.
+// Edge of triangle on the line is taken into account only if
+// triangle is located above the line.
+func (t triangle) width(c float64) float64 {
+	var xs []float64
+	for i := range t.index {
+		j := (i + 1) % 3
+		switch {
+		case t.y[i] == c && t.y[j] == c:
+			// edge on the line
+			k := (i + 2) % 3
+			if c < t.y[k] {
+				return math.Abs(t.x[i] - t.x[j])
+			}
+			return 0
+		case t.y[i] == c:
+			xs = append(xs, t.x[i])
+		case (t.y[i] < c && c < t.y[j]) || (t.y[j] < c && c < t.y[i]):
+			xs = append(xs, t.x[i]+(t.x[j]-t.x[i])*(c-t.y[i])/(t.y[j]-t.y[i]))
+		}
+	}
+	if len(xs) != 2 {
+		return 0
+	}
+	return math.Abs(xs[0] - xs[1])
+}
@@ -0,0 +1,244 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+	"github.com/Konstantin8105/msh"
+)
+
+// Forces is internal forces of section in axes of mesh with origin at
+// the center of section. Shear forces are applied in the shear center.
+//
+// Sign convention of bending moments:
+//
+//	positive moment Mx created tension stresses in fibers with y > 0
+//	positive moment My created tension stresses in fibers with x > 0
+type Forces struct {
+	N      float64 // axial force
+	Mx, My float64 // bending moments around axes X and Y
+	Vx, Vy float64 // shear forces by axes X and Y
+	T      float64 // torque
+}
+
+// StressPoint is stress value in node of mesh
+type StressPoint struct {
+	Value float64 // value of stress
+	Node  int     // index of node in mesh.Nodes
+	X, Y  float64 // coordinates of node
+}
+
+// Stress is stresses in nodes of mesh.
+//
+// Normal stress for general bending:
+//
+//	sigma = N/A + (Mx*Jyy - My*Jxy)/D*y + (My*Jxx - Mx*Jxy)/D*x
+//	D     = Jxx*Jyy - Jxy^2
+//	Jxy   = integral(x*y,dA)
+//
+// Shear stress is sum of Saint-Venant torsion and shear stresses from
+// shear forces, see TorsionProperty and ShearArea. Shear stress in node is
+// average of stresses in triangles around node.
+//
+// Von Mises stress:
+//
+//	sigma_eqv = sqrt(sigma^2 + 3*tau^2)
+type Stress struct {
+	Sigma      []float64 // normal stress
+	TauX, TauY []float64 // components of shear stress
+	Tau        []float64 // shear stress
+	VonMises   []float64 // von Mises equivalent stress
+
+	SigmaMax, SigmaMin StressPoint // extreme normal stresses
+	TauMax             StressPoint // maximal shear stress
+	VonMisesMax        StressPoint // maximal von Mises stress
+}
+
+func (s Stress) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Stress\tValue\tX\tY\t.\n")
+	for _, v := range []struct {
+		name string
+		sp   StressPoint
+	}{
+		{"SigmaMax", s.SigmaMax},
+		{"SigmaMin", s.SigmaMin},
+		{"TauMax", s.TauMax},
+		{"VonMisesMax", s.VonMisesMax},
+	} {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t.\n", v.name,
+			efmt.Sprint(v.sp.Value), efmt.Sprint(v.sp.X), efmt.Sprint(v.sp.Y))
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// Stresses return stresses in all nodes of mesh for internal forces.
+// Axes of forces are axes of mesh, see CalculateWithMesh. Warping and
+// shear functions are solved for each call with shear forces or torque.
+func Stresses(mesh msh.Msh, f Forces) (s *Stress, err error) {
+	m := mesh.Clone()
+	area, center := Area(m)
+	if area <= 0 {
+		err = fmt.Errorf("Area is not valid: %e", area)
+		return
+	}
+	MoveXOY(&m, -center.Coord[0], -center.Coord[1])
+	ss := newStressSolution(m)
+	return ss.stresses(f, mesh.Nodes)
+}
+
+// stressSolution is solution of warping and shear functions on mesh
+// located at the center of section
+type stressSolution struct {
+	mesh msh.Msh
+	ts   []triangle
+
+	// solved by solve
+	w  []float64 // warping function
+	it float64   // torsion constant
+	sf *shearFunction
+}
+
+func newStressSolution(mesh msh.Msh) *stressSolution {
+	m := mesh.Clone()
+	return &stressSolution{mesh: m, ts: triangles(m)}
+}
+
+// solve warping and shear functions, if it is not solved
+func (ss *stressSolution) solve() (err error) {
+	if ss.sf != nil {
+		return
+	}
+	w, err := warping(ss.mesh, ss.ts)
+	if err != nil {
+		return
+	}
+	var it float64
+	for _, t := range ss.ts {
+		xc, yc := t.center()
+		wx, wy := t.gradient(w)
+		it += t.integral(t.x, t.x) + t.integral(t.y, t.y) + t.area*(xc*wy-yc*wx)
+	}
+	sf, err := newShearFunction(ss.mesh)
+	if err != nil {
+		return
+	}
+	ss.w, ss.it, ss.sf = w, it, sf
+	return
+}
+
+// stresses return stresses for internal forces. Coordinates of extreme
+// values are coordinates of nodes.
+func (ss *stressSolution) stresses(f Forces, nodes []msh.Node) (s *Stress, err error) {
+	var (
+		m  = ss.mesh
+		ts = ss.ts
+	)
+	// normal stresses
+	var area, jxx, jyy, jxy float64
+	for _, t := range ts {
+		area += t.area
+		jxx += t.integral(t.y, t.y)
+		jyy += t.integral(t.x, t.x)
+		jxy += t.integral(t.x, t.y)
+	}
+	d := jxx*jyy - jxy*jxy
+	if d <= 0 {
+		err = fmt.Errorf("not valid moment of inertia")
+		return
+	}
+	size := len(m.Nodes)
+	s = new(Stress)
+	s.Sigma = make([]float64, size)
+	for i := range m.Nodes {
+		x, y := m.Nodes[i].Coord[0], m.Nodes[i].Coord[1]
+		s.Sigma[i] = f.N/area +
+			(f.Mx*jyy-f.My*jxy)/d*y +
+			(f.My*jxx-f.Mx*jxy)/d*x
+	}
+
+	// shear stresses
+	s.TauX = make([]float64, size)
+	s.TauY = make([]float64, size)
+	weight := make([]float64, size)
+	for _, t := range ts {
+		for _, n := range t.index {
+			weight[n] += t.area
+		}
+	}
+	if f.T != 0 || f.Vx != 0 || f.Vy != 0 {
+		if err = ss.solve(); err != nil {
+			return
+		}
+		var (
+			w  = ss.w
+			it = ss.it
+			sf = ss.sf
+		)
+		for _, t := range ts {
+			wx, wy := t.gradient(w)
+			psix, psiy := t.gradient(sf.psi)
+			phix, phiy := t.gradient(sf.phi)
+			tx := (f.Vx*psix + f.Vy*phix) / sf.delta
+			ty := (f.Vx*psiy + f.Vy*phiy) / sf.delta
+			for i, n := range t.index {
+				s.TauX[n] += t.area * (tx + f.T/it*(wx-t.y[i]))
+				s.TauY[n] += t.area * (ty + f.T/it*(wy+t.x[i]))
+			}
+		}
+	}
+	s.Tau = make([]float64, size)
+	s.VonMises = make([]float64, size)
+	for i := range m.Nodes {
+		if 0 < weight[i] {
+			s.TauX[i] /= weight[i]
+			s.TauY[i] /= weight[i]
+		}
+		s.Tau[i] = math.Hypot(s.TauX[i], s.TauY[i])
+		s.VonMises[i] = math.Sqrt(s.Sigma[i]*s.Sigma[i] + 3*s.Tau[i]*s.Tau[i])
+	}
+
+	// extreme values
+	point := func(i int, v float64) StressPoint {
+		return StressPoint{
+			Value: v,
+			Node:  i,
+			X:     nodes[i].Coord[0],
+			Y:     nodes[i].Coord[1],
+		}
+	}
+	first := true
+	for i := range m.Nodes {
+		if weight[i] == 0 {
+			// node is not part of triangles
+			continue
+		}
+		if first {
+			s.SigmaMax = point(i, s.Sigma[i])
+			s.SigmaMin = point(i, s.Sigma[i])
+			s.TauMax = point(i, s.Tau[i])
+			s.VonMisesMax = point(i, s.VonMises[i])
+			first = false
+			continue
+		}
+		if s.SigmaMax.Value < s.Sigma[i] {
+			s.SigmaMax = point(i, s.Sigma[i])
+		}
+		if s.Sigma[i] < s.SigmaMin.Value {
+			s.SigmaMin = point(i, s.Sigma[i])
+		}
+		if s.TauMax.Value < s.Tau[i] {
+			s.TauMax = point(i, s.Tau[i])
+		}
+		if s.VonMisesMax.Value < s.VonMises[i] {
+			s.VonMisesMax = point(i, s.VonMises[i])
+		}
+	}
+	return
+}
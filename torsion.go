@@ -0,0 +1,167 @@
+package section
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/Konstantin8105/efmt"
+	"github.com/Konstantin8105/msh"
+)
+
+// Saint-Venant torsion:
+//
+//	Warping function w(x,y):
+//	div(grad(w)) = 0         inside section
+//	dw/dn = y*nx - x*ny      on contour of section
+//
+//	Weak form:
+//	integral(grad(N)*grad(w),dA) = integral(y*dN/dx - x*dN/dy, dA)
+//
+//	Torsion constant:
+//	It = Jxx + Jyy + integral(x*dw/dy - y*dw/dx, dA)
+//
+//	Shear stress for torque T:
+//	tau_zx = T/It * (dw/dx - y)
+//	tau_zy = T/It * (dw/dy + x)
+//
+//	Shear center (xs,ys) is pole of warping function with zero
+//	sectorial products of area. Warping function for pole (xs,ys):
+//	ws = w - ys*x + xs*y
+//	integral(x*ws,dA) = 0
+//	integral(y*ws,dA) = 0
+//
+//	Warping constant:
+//	Iw = integral(ws^2,dA) - integral(ws,dA)^2/A
+//
+// See https://en.wikipedia.org/wiki/Torsion_constant
+// See https://en.wikipedia.org/wiki/Warping_constant
+type TorsionProperty struct {
+	It  float64 // torsion constant
+	Tau float64 // maximal shear stress for unit torque
+	Wt  float64 // torsional section modulus, Wt = 1/Tau
+	Iw  float64 // warping constant
+
+	// Closed cells of thin-walled section by Bredt-Batho theory.
+	// See Cell.
+	ItBredt float64 // torsion constant of closed cells
+	Cells   []Cell  // closed cells
+	Walls   []Wall  // walls of closed cells
+}
+
+func (t TorsionProperty) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "It\t%s\tTorsion constant\n", efmt.Sprint(t.It))
+	fmt.Fprintf(w, "Tau\t%s\tMaximal shear stress for unit torque\n", efmt.Sprint(t.Tau))
+	fmt.Fprintf(w, "Wt\t%s\tTorsional moment resistance\n", efmt.Sprint(t.Wt))
+	fmt.Fprintf(w, "Iw\t%s\tWarping constant\n", efmt.Sprint(t.Iw))
+	if 0 < len(t.Cells) {
+		fmt.Fprintf(w, "ItBredt\t%s\tTorsion constant of closed cells\n", efmt.Sprint(t.ItBredt))
+		for i, c := range t.Cells {
+			fmt.Fprintf(w, "Cell %d\t%s\tArea enclosed by median line\n", i, efmt.Sprint(c.Area))
+			fmt.Fprintf(w, "\t%s\tShear flow for unit torque\n", efmt.Sprint(c.Flow))
+		}
+		for _, wl := range t.Walls {
+			fmt.Fprintf(w, "Wall %d:%d\t%s\tShear flow in wall for unit torque\n",
+				wl.Cells[0], wl.Cells[1], efmt.Sprint(wl.Flow))
+		}
+	}
+	fmt.Fprintf(w, "\n")
+	w.Flush()
+	return buf.String()
+}
+
+// Calculate torsion property on mesh by finite element solution of
+// warping function. Mesh must be located at the center of section.
+// Return location of shear center.
+func (t *TorsionProperty) Calculate(mesh msh.Msh) (xs, ys float64, err error) {
+	ts := triangles(mesh)
+	w, err := warping(mesh, ts)
+	if err != nil {
+		return
+	}
+	return t.calculate(mesh, ts, w)
+}
+
+// calculate torsion property by warping function w in nodes of mesh
+func (t *TorsionProperty) calculate(mesh msh.Msh, ts []triangle, w []float64) (xs, ys float64, err error) {
+	var jo, wf float64
+	for _, tr := range ts {
+		xc, yc := tr.center()
+		wx, wy := tr.gradient(w)
+		jo += tr.integral(tr.x, tr.x) + tr.integral(tr.y, tr.y)
+		wf += tr.area * (xc*wy - yc*wx)
+	}
+	t.It = jo + wf
+	if t.It <= 0 {
+		err = fmt.Errorf("torsion constant is not valid: %e", t.It)
+		return
+	}
+	var tau float64
+	for _, tr := range ts {
+		wx, wy := tr.gradient(w)
+		for i := range tr.index {
+			v := math.Hypot(wx-tr.y[i], wy+tr.x[i])
+			tau = math.Max(tau, v)
+		}
+	}
+	t.Tau = tau / t.It
+	t.Wt = 1.0 / t.Tau
+
+	// shear center
+	var jxx, jyy, jxy, jxw, jyw float64
+	for _, tr := range ts {
+		var wn [3]float64
+		for i := range tr.index {
+			wn[i] = w[tr.index[i]]
+		}
+		jxx += tr.integral(tr.y, tr.y)
+		jyy += tr.integral(tr.x, tr.x)
+		jxy += tr.integral(tr.x, tr.y)
+		jxw += tr.integral(tr.x, wn)
+		jyw += tr.integral(tr.y, wn)
+	}
+	delta := jxx*jyy - jxy*jxy
+	xs = (jxy*jxw - jyy*jyw) / delta
+	ys = (jxx*jxw - jxy*jyw) / delta
+
+	// warping constant
+	var area, sw, sww float64
+	for _, tr := range ts {
+		var wn, one [3]float64
+		for i := range tr.index {
+			wn[i] = w[tr.index[i]] - ys*tr.x[i] + xs*tr.y[i]
+			one[i] = 1.0
+		}
+		area += tr.area
+		sw += tr.integral(wn, one)
+		sww += tr.integral(wn, wn)
+	}
+	t.Iw = sww - sw*sw/area
+
+	// closed cells
+	t.ItBredt, t.Cells, t.Walls, err = bredt(mesh, ts)
+	return
+}
+
+// warping return values of warping function in mesh nodes
+func warping(mesh msh.Msh, ts []triangle) (w []float64, err error) {
+	k, fix := laplace(mesh, ts)
+	f := make([]float64, len(mesh.Nodes))
+	for _, t := range ts {
+		xc, yc := t.center()
+		for i := range t.index {
+			f[t.index[i]] += t.area * (yc*t.dx[i] - xc*t.dy[i])
+		}
+	}
+	for _, n := range fix {
+		f[n] = 0
+	}
+	w, err = k.solve(f)
+	if err != nil {
+		err = fmt.Errorf("warping function: %v", err)
+	}
+	return
+}
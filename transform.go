@@ -0,0 +1,147 @@
+package section
+
+import (
+	"fmt"
+	"math"
+)
+
+// Mirror is section mirrored by axe Y (x -> -x), or by axe X (y -> -y)
+// for ByAxeX = true. Section must be Bounder.
+//
+// Example of channel facing left:
+//
+//	Mirror{Geor: UPNs[0]}
+type Mirror struct {
+	Geor
+	ByAxeX bool // mirror by axe X
+}
+
+func (m Mirror) GetName() string {
+	axe := "Y"
+	if m.ByAxeX {
+		axe = "X"
+	}
+	return fmt.Sprintf("%s, mirror by axe %s", m.Geor.GetName(), axe)
+}
+
+func (m Mirror) Geo(prec float64) string {
+	return BoundaryGeo(m.Boundaries(), prec)
+}
+
+func (m Mirror) Validate() error {
+	return validateBounder(m.Geor)
+}
+
+// Boundaries of Mirror
+func (m Mirror) Boundaries() []Boundary {
+	if m.ByAxeX {
+		// mirror by axe X is mirror by axe Y with rotation on 180 degree
+		return transformed(m.Geor, true, math.Pi, 0, 0)
+	}
+	return transformed(m.Geor, true, 0, 0, 0)
+}
+
+// Rotate is section rotated counterclockwise on angle around origin.
+// Section must be Bounder.
+//
+// Example of angle turned on 180 degree:
+//
+//	Rotate{Geor: Angles[0], Angle: math.Pi}
+type Rotate struct {
+	Geor
+	Angle float64 // radian
+}
+
+func (r Rotate) GetName() string {
+	return fmt.Sprintf("%s, rotate %.2f degree", r.Geor.GetName(), r.Angle*180/math.Pi)
+}
+
+func (r Rotate) Geo(prec float64) string {
+	return BoundaryGeo(r.Boundaries(), prec)
+}
+
+func (r Rotate) Validate() error {
+	return validateBounder(r.Geor)
+}
+
+// Boundaries of Rotate
+func (r Rotate) Boundaries() []Boundary {
+	return transformed(r.Geor, false, r.Angle, 0, 0)
+}
+
+// Translate is section moved on (X,Y). Section must be Bounder.
+type Translate struct {
+	Geor
+	X, Y float64
+}
+
+func (t Translate) GetName() string {
+	return fmt.Sprintf("%s, move X%.2f Y%.2f", t.Geor.GetName(), 1e3*t.X, 1e3*t.Y)
+}
+
+func (t Translate) Geo(prec float64) string {
+	return BoundaryGeo(t.Boundaries(), prec)
+}
+
+func (t Translate) Validate() error {
+	return validateBounder(t.Geor)
+}
+
+// Boundaries of Translate
+func (t Translate) Boundaries() []Boundary {
+	return transformed(t.Geor, false, 0, t.X, t.Y)
+}
+
+// validateBounder return error for section without boundaries or not
+// valid section
+func validateBounder(g Geor) error {
+	if _, ok := g.(Bounder); !ok {
+		return fmt.Errorf("section is not Bounder")
+	}
+	if v, ok := g.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// transformed return transformed boundaries of section, see transform.
+// Result is nil for section without boundaries.
+func transformed(g Geor, mirror bool, a, dx, dy float64) []Boundary {
+	b, ok := g.(Bounder)
+	if !ok {
+		return nil
+	}
+	return transform(b.Boundaries(), mirror, a, dx, dy)
+}
+
+// transform return boundaries mirrored by axe Y for mirror = true, then
+// rotated on angle `a` around origin, then moved on (dx,dy)
+func transform(bs []Boundary, mirror bool, a, dx, dy float64) (res []Boundary) {
+	sin, cos := math.Sin(a), math.Cos(a)
+	point := func(p Point) Point {
+		if mirror {
+			p.X = -p.X
+		}
+		return Point{
+			X: p.X*cos - p.Y*sin + dx,
+			Y: p.X*sin + p.Y*cos + dy,
+		}
+	}
+	loop := func(l Loop) (r Loop) {
+		for _, s := range l {
+			s.Begin = point(s.Begin)
+			s.End = point(s.End)
+			s.Center = point(s.Center)
+			r = append(r, s)
+		}
+		return
+	}
+	for _, b := range bs {
+		t := Boundary{Outer: loop(b.Outer)}
+		for _, h := range b.Holes {
+			t.Holes = append(t.Holes, loop(h))
+		}
+		res = append(res, t)
+	}
+	return
+}